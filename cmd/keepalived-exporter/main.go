@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/mehdy/keepalived-exporter/internal/collector"
@@ -16,17 +19,94 @@ import (
 	common_version "github.com/prometheus/common/version"
 )
 
+// targetsFlag collects Keepalived daemons of repeated ka.target flags.
+type targetsFlag []host.Target
+
+func (t *targetsFlag) String() string {
+	names := make([]string, 0, len(*t))
+	for _, target := range *t {
+		names = append(names, target.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (t *targetsFlag) Set(value string) error {
+	target, err := host.ParseTarget(value)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range *t {
+		if existing.Name == target.Name {
+			return fmt.Errorf("duplicate target name %s", target.Name)
+		}
+	}
+
+	*t = append(*t, target)
+
+	return nil
+}
+
 func main() {
 	listenAddr := flag.String("web.listen-address", ":9165", "Address to listen on for web interface and telemetry.")
 	metricsPath := flag.String("web.telemetry-path", "/metrics", "A path under which to expose metrics.")
 	keepalivedJSON := flag.Bool("ka.json", false, "Send SIGJSON and decode JSON file instead of parsing text files.")
 	keepalivedPID := flag.String("ka.pid-path", "/var/run/keepalived.pid", "A path for Keepalived PID")
+	keepalivedTmpDir := flag.String(
+		"ka.tmp-dir",
+		"",
+		"Keepalived tmp directory of dump files, defaults to the tmp directory seen by the Keepalived process.",
+	)
 	keepalivedContainerPID := flag.String("ka.container.pid-path", "", "A path for Keepalived PID in container mode")
+	keepalivedChecker := flag.Bool(
+		"ka.checker",
+		false,
+		"Parse keepalived_check.data and export LVS virtual and real server status (not supported with ka.json).",
+	)
+	keepalivedBFD := flag.Bool(
+		"ka.bfd",
+		false,
+		"Parse keepalived_bfd.data and export BFD session states (not supported with ka.json).",
+	)
+	keepalivedIPVS := flag.Bool(
+		"ka.ipvs",
+		false,
+		"Export IPVS traffic statistics, limited to virtual servers of keepalived_check.data with ka.checker.",
+	)
+	keepalivedVerifyVIPs := flag.Bool(
+		"ka.verify-vips",
+		false,
+		"Compare VIPs with the kernel addresses of their interfaces via netlink.",
+	)
+	keepalivedVerifyRoutes := flag.Bool(
+		"ka.verify-routes",
+		false,
+		"Look up virtual routes and rules in the kernel routing tables via netlink.",
+	)
+	keepalivedInstanceStateSet := flag.Bool(
+		"ka.instance-state-set",
+		false,
+		"Export keepalived_vrrp_instance_state as one series per VRRP state instead of a numeric state.",
+	)
+	keepalivedPollInterval := flag.Duration(
+		"ka.poll-interval",
+		0,
+		"Refresh keepalived data in background on this interval and serve scrapes from the latest snapshot, disabled when 0.",
+	)
 	keepalivedCheckScript := flag.String("cs", "", "Health Check script path to be execute for each VIP")
 	keepalivedContainerName := flag.String("container-name", "", "Keepalived container name")
 	keepalivedContainerTmpDir := flag.String("container-tmp-dir", "/tmp", "Keepalived container tmp volume path")
 	versionFlag := flag.Bool("version", false, "Show the current keepalived exporter version")
 
+	var keepalivedTargets targetsFlag
+	flag.Var(
+		&keepalivedTargets,
+		"ka.target",
+		"Keepalived daemon to export with keepalived label, like name=r1,pid-path=/var/run/r1.pid,tmp-dir=/tmp,namespace=r1. "+
+			"Can be repeated, not supported with ka.pid-path and ka.tmp-dir.",
+	)
+
 	flag.Parse()
 
 	if *versionFlag {
@@ -40,34 +120,90 @@ func main() {
 		return
 	}
 
-	var c collector.Collector
-	if *keepalivedContainerName != "" {
-		c = container.NewKeepalivedContainerCollectorHost(
+	if *keepalivedJSON && *keepalivedChecker {
+		slog.Error("ka.checker is not supported with ka.json")
+		os.Exit(1)
+	}
+
+	if *keepalivedJSON && *keepalivedBFD {
+		slog.Error("ka.bfd is not supported with ka.json")
+		os.Exit(1)
+	}
+
+	if len(keepalivedTargets) > 0 && *keepalivedContainerName != "" {
+		slog.Error("ka.target is not supported with container-name")
+		os.Exit(1)
+	}
+
+	if len(keepalivedTargets) > 0 {
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "ka.pid-path" || f.Name == "ka.tmp-dir" {
+				slog.Error(f.Name + " is not supported with ka.target, set pid-path and tmp-dir of the target instead")
+				os.Exit(1)
+			}
+		})
+	}
+
+	if err := host.ValidateTargets(keepalivedTargets); err != nil {
+		slog.Error("Invalid ka.target", "error", err)
+		os.Exit(1)
+	}
+
+	// collectors of targets by name, the only unnamed one is exported without keepalived label
+	collectors := make(map[string]collector.Collector)
+
+	switch {
+	case *keepalivedContainerName != "":
+		collectors[""] = container.NewKeepalivedContainerCollectorHost(
 			*keepalivedJSON,
 			*keepalivedContainerName,
 			*keepalivedContainerTmpDir,
 			*keepalivedContainerPID,
 		)
-	} else {
-		c = host.NewKeepalivedHostCollectorHost(*keepalivedJSON, *keepalivedPID)
+	case len(keepalivedTargets) > 0:
+		for _, t := range keepalivedTargets {
+			collectors[t.Name] = host.NewKeepalivedHostCollectorHost(*keepalivedJSON, t.PIDPath, t.TmpDir, t.Namespace)
+		}
+	default:
+		collectors[""] = host.NewKeepalivedHostCollectorHost(*keepalivedJSON, *keepalivedPID, *keepalivedTmpDir, "")
 	}
 
-	// json support check
-	if *keepalivedJSON {
-		jsonSupport, err := c.HasJSONSignalSupport()
-		if err != nil {
-			slog.Error("Error checking JSON signal support", "error", err)
-			os.Exit(1)
+	for name, c := range collectors {
+		// json support check
+		if *keepalivedJSON {
+			jsonSupport, err := c.HasJSONSignalSupport()
+			if err != nil {
+				slog.Error("Error checking JSON signal support", "error", err, "target", name)
+				os.Exit(1)
+			}
+
+			if !jsonSupport {
+				slog.Error("Keepalived does not support JSON signal. Please use a version that supports it.", "target", name)
+				os.Exit(1)
+			}
+		}
+
+		registerer := collector.TargetRegisterer(prometheus.DefaultRegisterer, name)
+
+		keepalivedCollector := collector.NewKeepalivedCollector(collector.Options{
+			JSON:             *keepalivedJSON,
+			Checker:          *keepalivedChecker,
+			BFD:              *keepalivedBFD,
+			VerifyVIPs:       *keepalivedVerifyVIPs,
+			VerifyRoutes:     *keepalivedVerifyRoutes,
+			InstanceStateSet: *keepalivedInstanceStateSet,
+			ScriptPath:       *keepalivedCheckScript,
+		}, c)
+		if *keepalivedPollInterval > 0 {
+			keepalivedCollector.StartPolling(context.Background(), *keepalivedPollInterval)
 		}
+		registerer.MustRegister(keepalivedCollector)
 
-		if !jsonSupport {
-			slog.Error("Keepalived does not support JSON signal. Please use a version that supports it.")
-			os.Exit(1)
+		if *keepalivedIPVS {
+			registerer.MustRegister(collector.NewIPVSCollector(c, keepalivedCollector))
 		}
 	}
 
-	keepalivedCollector := collector.NewKeepalivedCollector(*keepalivedJSON, *keepalivedCheckScript, c)
-	prometheus.MustRegister(keepalivedCollector)
 	prometheus.MustRegister(version.NewCollector("keepalived_exporter"))
 
 	http.Handle(*metricsPath, promhttp.Handler())
@@ -5,31 +5,48 @@ import (
 	"errors"
 	"log/slog"
 	"os/exec"
+	"slices"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
 )
 
 type Collector interface {
-	Refresh() error
+	// Refresh signals keepalived and waits for its dumps, along with the extra dump files of names in dumps.
+	Refresh(dumps ...string) error
 	ScriptVrrps() ([]VRRPScript, error)
 	DataVrrps() (map[string]*VRRPData, error)
 	StatsVrrps() (map[string]*VRRPStats, error)
 	JSONVrrps() ([]VRRP, error)
+	SyncGroupVrrps() ([]VRRPSyncGroup, error)
+	TrackFileVrrps() ([]VRRPTrackFile, error)
+	TrackProcessVrrps() ([]VRRPTrackProcess, error)
+	GlobalDefinitions() (*GlobalDefinitions, error)
+	CheckerVirtualServers() ([]VirtualServer, error)
+	BFDInstances() ([]BFDInstance, error)
+	IPVSServices() ([]IPVSService, error)
+	InterfaceAddresses() (map[string][]string, error)
+	RoutingTables() (*RoutingTables, error)
+	KeepalivedVersion() string
 	HasVRRPScriptStateSupport() bool
 	HasJSONSignalSupport() (bool, error)
 }
 
 // KeepalivedCollector implements prometheus.Collector interface and stores required info to collect data.
+// The embedded mutex only guards snapshot replacement.
 type KeepalivedCollector struct {
 	sync.Mutex
-	useJSON    bool
-	scriptPath string
-	metrics    map[string]*prometheus.Desc
-	collector  Collector
+	refreshGroup singleflight.Group
+	options      Options
+	polling      bool
+	snapshot     *snapshot
+	staleDumps   atomic.Uint64
+	metrics      map[string]*prometheus.Desc
+	collector    Collector
 }
 
 // VRRPStats represents Keepalived stats about VRRP.
@@ -52,21 +69,183 @@ type VRRPStats struct {
 
 // VRRPData represents Keepalived data about VRRP.
 type VRRPData struct {
-	IName        string   `json:"iname"`
-	State        int      `json:"state"`
-	WantState    int      `json:"wantstate"`
-	Intf         string   `json:"ifp_ifname"`
-	GArpDelay    int      `json:"garp_delay"`
-	VRID         int      `json:"vrid"`
-	VIPs         []string `json:"vips"`
-	ExcludedVIPs []string `json:"evips"`
+	IName             string                 `json:"iname"`
+	State             int                    `json:"state"`
+	WantState         int                    `json:"wantstate"`
+	Intf              string                 `json:"ifp_ifname"`
+	BaseIntf          string                 `json:"-"`
+	VMACIntf          string                 `json:"vmac_ifname"`
+	MAC               string                 `json:"-"`
+	GArpDelay         int                    `json:"garp_delay"`
+	VRID              int                    `json:"vrid"`
+	Priority          int                    `json:"base_priority"`
+	EffectivePriority int                    `json:"effective_priority"`
+	TotalPriority     int                    `json:"total_priority"`
+	LastTransition    float64                `json:"last_transition"`
+	ReadTimeout       float64                `json:"-"`
+	ConfigFaults      int                    `json:"-"`
+	TrackFaults       int                    `json:"-"`
+	TrackScriptsInit  int                    `json:"-"`
+	AdvertInterval    float64                `json:"adver_int"`
+	MasterDownTimer   float64                `json:"master_down_timer"`
+	DownTimerAdverts  int                    `json:"down_timer_adverts"`
+	VIPs              []VIP                  `json:"vips"`
+	ExcludedVIPs      []VIP                  `json:"evips"`
+	TrackedScripts    []VRRPTrackedScript    `json:"track_script"`
+	TrackedInterfaces []VRRPTrackedInterface `json:"track_ifp"`
+	TrackedBFDs       []VRRPTrackedBFD       `json:"-"`
+	SrcIP             string                 `json:"-"`
+	McastGroup        string                 `json:"-"`
+	UnicastPeers      []string               `json:"-"`
+	VirtualRoutes     []VirtualRoute         `json:"-"`
+	VirtualRules      []VirtualRule          `json:"-"`
+}
+
+// VIP represents a virtual IP address of a VRRP instance like "10.0.0.100/24 dev eth0 scope global set".
+type VIP struct {
+	// Raw is the address as dumped by keepalived like "10.0.0.100/24", it's kept even when the VIP fails to parse
+	Raw     string
+	Address string
+	Prefix  int
+	Family  string
+	Device  string
+	Scope   string
+	Label   string
+	Set     bool
+}
+
+// VirtualRoute represents a route installed by a VRRP instance, identified by its destination, family, nexthop, metric and table.
+// Empty family and nexthop or zero metric and table of a virtual route match any.
+// Table 0 matches any table when its name could not be resolved.
+type VirtualRoute struct {
+	Dst    string
+	Family string
+	Via    string
+	Metric int
+	Table  int
+}
+
+// VirtualRule represents a routing rule installed by a VRRP instance, identified by its selectors and table.
+type VirtualRule struct {
+	From  string
+	To    string
+	Table int
+}
+
+// RoutingTables holds the routes and rules found in the kernel.
+type RoutingTables struct {
+	Routes []VirtualRoute
+	Rules  []VirtualRule
+}
+
+// VRRPTrackedInterface represents an interface tracked by a VRRP instance, its weight and its UP or DOWN status.
+type VRRPTrackedInterface struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	Status string `json:"status"`
+}
+
+// VRRPTrackedScript represents a script tracked by a VRRP instance and its weight.
+type VRRPTrackedScript struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+}
+
+// VRRPTrackedBFD represents a BFD instance tracked by a VRRP instance and its weight.
+type VRRPTrackedBFD struct {
+	Name   string
+	Weight int
+}
+
+// BFDInstance represents a Keepalived bfd_instance session with its neighbor.
+type BFDInstance struct {
+	Name                string
+	Neighbor            string
+	LocalState          int
+	RemoteState         int
+	LocalDiscriminator  uint32
+	RemoteDiscriminator uint32
+	Transitions         int
 }
 
 // VRRPScript represents Keepalived script about VRRP.
 type VRRPScript struct {
+	Name      string
+	Command   string
+	Interval  float64
+	Timeout   float64
+	Weight    int
+	Rise      int
+	Fall      int
+	Result    int
+	InitState string
+	Status    string
+	State     string
+	Instances []VRRPScriptInstance
+}
+
+// VRRPScriptInstance represents a VRRP instance tracking a script and the weight it applies.
+type VRRPScriptInstance struct {
+	IName  string
+	Weight int
+}
+
+// VRRPTrackFile represents Keepalived vrrp_track_file and the value read from its file.
+type VRRPTrackFile struct {
 	Name   string
-	Status string
-	State  string
+	Path   string
+	Value  int
+	Weight int
+}
+
+// VRRPTrackProcess represents Keepalived vrrp_track_process and the number of its running processes.
+type VRRPTrackProcess struct {
+	Name    string
+	Process string
+	Count   int
+	Up      bool
+	Weight  int
+}
+
+// VRRPSyncGroup represents Keepalived VRRP sync group.
+type VRRPSyncGroup struct {
+	Name          string
+	State         int
+	Instances     []string
+	NotifyScripts map[string]string
+}
+
+// GlobalDefinitions represents Keepalived global definitions.
+type GlobalDefinitions struct {
+	RouterID          string
+	InstanceName      string
+	Namespace         string
+	DynamicInterfaces bool
+	ScriptSecurity    bool
+	StartupTime       float64
+	ConfigLoadTime    float64
+}
+
+// VirtualServer represents Keepalived LVS virtual server from keepalived_check.data.
+type VirtualServer struct {
+	Name        string
+	Quorum      int
+	QuorumUp    bool
+	RealServers []RealServer
+}
+
+// RealServer represents Keepalived LVS real server and its health checkers.
+type RealServer struct {
+	Name     string
+	Weight   int
+	Alive    bool
+	Checkers []RealServerChecker
+}
+
+// RealServerChecker represents a health checker like HTTP_GET, TCP_CHECK or MISC_CHECK of a real server.
+type RealServerChecker struct {
+	Type string
+	Up   bool
 }
 
 // VRRP ties together VRRPData and VRRPStats.
@@ -75,18 +254,23 @@ type VRRP struct {
 	Stats VRRPStats `json:"stats"`
 }
 
-// KeepalivedStats ties together VRRP and VRRPScript.
+// KeepalivedStats ties together GlobalDefinitions, VRRP, VRRPScript, VRRPSyncGroup, VirtualServer and BFDInstance.
 type KeepalivedStats struct {
-	VRRPs   []VRRP
-	Scripts []VRRPScript
+	Global         *GlobalDefinitions
+	VRRPs          []VRRP
+	Scripts        []VRRPScript
+	SyncGroups     []VRRPSyncGroup
+	TrackFiles     []VRRPTrackFile
+	TrackProcesses []VRRPTrackProcess
+	VirtualServers []VirtualServer
+	BFDs           []BFDInstance
 }
 
 // NewKeepalivedCollector is creating new instance of KeepalivedCollector.
-func NewKeepalivedCollector(useJSON bool, scriptPath string, collector Collector) *KeepalivedCollector {
+func NewKeepalivedCollector(options Options, collector Collector) *KeepalivedCollector {
 	kc := &KeepalivedCollector{
-		useJSON:    useJSON,
-		scriptPath: scriptPath,
-		collector:  collector,
+		options:   options,
+		collector: collector,
 	}
 
 	kc.fillMetrics()
@@ -94,6 +278,15 @@ func NewKeepalivedCollector(useJSON bool, scriptPath string, collector Collector
 	return kc
 }
 
+// TargetRegisterer returns the registerer of target name, named targets are exported with keepalived label.
+func TargetRegisterer(registerer prometheus.Registerer, name string) prometheus.Registerer {
+	if name == "" {
+		return registerer
+	}
+
+	return prometheus.WrapRegistererWith(prometheus.Labels{"keepalived": name}, registerer)
+}
+
 func (k *KeepalivedCollector) newConstMetric(
 	ch chan<- prometheus.Metric,
 	name string,
@@ -123,34 +316,18 @@ func (k *KeepalivedCollector) newConstMetric(
 }
 
 // Collect get metrics and add to prometheus metric channel.
+// When polling, the latest snapshot is exported instead of refreshing keepalived on every scrape.
+// Otherwise concurrent scrapes share the result of a single refresh.
 func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
-	k.Lock()
-	defer k.Unlock()
-
-	keepalivedUp := float64(1)
+	s := k.currentSnapshot()
 
-	b := backoff.NewExponentialBackOff()
-	b.InitialInterval = 10 * time.Millisecond
-	b.MaxElapsedTime = 2 * time.Second
-	b.Reset()
+	k.newConstMetric(ch, "keepalived_exporter_snapshot_age_seconds", prometheus.GaugeValue, time.Since(s.time).Seconds())
+	k.newConstMetric(ch, "keepalived_exporter_refresh_duration_seconds", prometheus.GaugeValue, s.duration.Seconds())
+	k.newConstMetric(ch, "keepalived_exporter_stale_dump_total", prometheus.CounterValue, float64(k.staleDumps.Load()))
 
-	var keepalivedStats *KeepalivedStats
-
-	if err := backoff.Retry(func() error {
-		var err error
-		keepalivedStats, err = k.getKeepalivedStats()
-		if err != nil {
-			slog.Debug("Failed to get keepalived stats",
-				"error", err,
-				"retryAfter", b.NextBackOff().String(),
-			)
-		}
-
-		return err
-	}, b); err != nil {
-		slog.Error("No data found to be exported", "error", err)
-
-		keepalivedUp = 0
+	keepalivedUp := float64(0)
+	if s.stats != nil {
+		keepalivedUp = 1
 	}
 
 	k.newConstMetric(ch, "keepalived_up", prometheus.GaugeValue, keepalivedUp)
@@ -159,6 +336,10 @@ func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 
+	keepalivedStats, addresses, routingTables := s.stats, s.addresses, s.routingTables
+
+	k.collectGlobalDefinitions(ch, keepalivedStats.Global)
+
 	for _, vrrp := range keepalivedStats.VRRPs {
 		k.newConstMetric(
 			ch,
@@ -295,13 +476,236 @@ func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
 			vrrp.Data.Intf,
 			strconv.Itoa(vrrp.Data.VRID),
 		)
+		k.newConstMetric(
+			ch,
+			"keepalived_vrrp_priority",
+			prometheus.GaugeValue,
+			float64(vrrp.Data.Priority),
+			vrrp.Data.IName,
+			vrrp.Data.Intf,
+			strconv.Itoa(vrrp.Data.VRID),
+		)
+		k.newConstMetric(
+			ch,
+			"keepalived_vrrp_effective_priority",
+			prometheus.GaugeValue,
+			float64(vrrp.Data.EffectivePriority),
+			vrrp.Data.IName,
+			vrrp.Data.Intf,
+			strconv.Itoa(vrrp.Data.VRID),
+		)
 
-		for _, ip := range vrrp.Data.VIPs {
-			ipAddr, intf, ok := ParseVIP(ip)
-			if !ok {
+		// total priority is not dumped in JSON
+		if !k.options.JSON {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_total_priority",
+				prometheus.GaugeValue,
+				float64(vrrp.Data.TotalPriority),
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+		}
+
+		k.collectInstanceState(ch, vrrp.Data)
+		k.newConstMetric(
+			ch,
+			"keepalived_vrrp_wantstate",
+			prometheus.GaugeValue,
+			float64(vrrp.Data.WantState),
+			vrrp.Data.IName,
+			vrrp.Data.Intf,
+			strconv.Itoa(vrrp.Data.VRID),
+		)
+
+		stateConverged := float64(0)
+		if vrrp.Data.State == vrrp.Data.WantState {
+			stateConverged = 1
+		}
+
+		k.newConstMetric(
+			ch,
+			"keepalived_vrrp_state_converged",
+			prometheus.GaugeValue,
+			stateConverged,
+			vrrp.Data.IName,
+			vrrp.Data.Intf,
+			strconv.Itoa(vrrp.Data.VRID),
+		)
+
+		// fault counters are not dumped in JSON
+		if !k.options.JSON {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_config_faults",
+				prometheus.GaugeValue,
+				float64(vrrp.Data.ConfigFaults),
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_track_faults",
+				prometheus.GaugeValue,
+				float64(vrrp.Data.TrackFaults),
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_track_scripts_init",
+				prometheus.GaugeValue,
+				float64(vrrp.Data.TrackScriptsInit),
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+		}
+
+		// advert interval is skipped when its unit is unknown
+		if vrrp.Data.AdvertInterval > 0 {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_advert_interval_seconds",
+				prometheus.GaugeValue,
+				vrrp.Data.AdvertInterval,
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+		}
+
+		// master down timer and down timer adverts are not dumped by older releases and JSON
+		if vrrp.Data.MasterDownTimer > 0 {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_master_down_timer_seconds",
+				prometheus.GaugeValue,
+				vrrp.Data.MasterDownTimer,
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+		}
+
+		if vrrp.Data.DownTimerAdverts > 0 {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_down_timer_adverts",
+				prometheus.GaugeValue,
+				float64(vrrp.Data.DownTimerAdverts),
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+		}
+
+		k.newConstMetric(
+			ch,
+			"keepalived_vrrp_last_transition_timestamp_seconds",
+			prometheus.GaugeValue,
+			vrrp.Data.LastTransition,
+			vrrp.Data.IName,
+			vrrp.Data.Intf,
+			strconv.Itoa(vrrp.Data.VRID),
+		)
+
+		// read timeout is not dumped in JSON
+		if vrrp.Data.ReadTimeout > 0 {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_read_timeout_timestamp_seconds",
+				prometheus.GaugeValue,
+				vrrp.Data.ReadTimeout,
+				vrrp.Data.IName,
+				vrrp.Data.Intf,
+				strconv.Itoa(vrrp.Data.VRID),
+			)
+		}
+
+		for _, intf := range vrrp.Data.TrackedInterfaces {
+			// interface status is only known when keepalived dumps its interfaces
+			if intf.Status == "" {
+				continue
+			}
+
+			intfUp := float64(0)
+			if intf.Status == "UP" {
+				intfUp = 1
+			}
+
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_tracked_interface_up",
+				prometheus.GaugeValue,
+				intfUp,
+				vrrp.Data.IName,
+				intf.Name,
+				strconv.Itoa(intf.Weight),
+			)
+		}
+
+		for _, script := range vrrp.Data.trackedScripts(keepalivedStats.Scripts) {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_tracked_script_info",
+				prometheus.GaugeValue,
+				1,
+				vrrp.Data.IName,
+				script.Name,
+				strconv.Itoa(script.Weight),
+			)
+		}
+
+		for _, bfd := range vrrp.Data.TrackedBFDs {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_tracked_bfd_info",
+				prometheus.GaugeValue,
+				1,
+				vrrp.Data.IName,
+				bfd.Name,
+				strconv.Itoa(bfd.Weight),
+			)
+		}
+
+		k.collectVIPs(ch, vrrp.Data)
+		k.collectTransport(ch, vrrp.Data)
+
+		if vrrp.Data.VMACIntf != "" {
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_vmac_info",
+				prometheus.GaugeValue,
+				1,
+				vrrp.Data.IName,
+				vrrp.Data.BaseIntf,
+				vrrp.Data.VMACIntf,
+				vrrp.Data.MAC,
+			)
+		}
+
+		if addresses != nil {
+			k.collectVIPPresence(ch, vrrp.Data, addresses)
+		}
+
+		if routingTables != nil {
+			k.collectVirtualRoutesPresence(ch, vrrp.Data, routingTables)
+		}
+
+		stateSeries := 0
+
+		for _, vip := range vrrp.Data.VIPs {
+			if vip.Raw == "" {
 				continue
 			}
 
+			ipAddr, intf := vip.Raw, vip.intf(vrrp.Data.Intf)
+			stateSeries++
+
 			k.newConstMetric(
 				ch,
 				"keepalived_vrrp_state",
@@ -313,7 +717,7 @@ func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
 				ipAddr,
 			)
 
-			if k.scriptPath != "" {
+			if k.options.ScriptPath != "" {
 				checkScript := float64(0)
 				if ok := k.checkScript(ipAddr); ok {
 					checkScript = 1
@@ -333,12 +737,13 @@ func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
 		}
 
 		// iter over excluded vips
-		for _, ip := range vrrp.Data.ExcludedVIPs {
-			ipAddr, intf, ok := ParseVIP(ip)
-			if !ok {
+		for _, vip := range vrrp.Data.ExcludedVIPs {
+			if vip.Raw == "" {
 				continue
 			}
 
+			ipAddr, intf := vip.Raw, vip.intf(vrrp.Data.Intf)
+
 			k.newConstMetric(
 				ch,
 				"keepalived_vrrp_excluded_state",
@@ -351,8 +756,8 @@ func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
 			)
 		}
 
-		// record vrrp_state metric even when VIPs are not defined, to support old keepalived release
-		if len(vrrp.Data.VIPs) == 0 {
+		// record vrrp_state metric even when no VIP is exported, to support old keepalived release and empty JSON VIPs
+		if stateSeries == 0 {
 			k.newConstMetric(
 				ch,
 				"keepalived_vrrp_state",
@@ -366,6 +771,31 @@ func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
 		}
 	}
 
+	k.collectVirtualServers(ch, keepalivedStats.VirtualServers)
+	k.collectBFDs(ch, keepalivedStats.BFDs)
+
+	for _, file := range keepalivedStats.TrackFiles {
+		k.newConstMetric(ch, "keepalived_track_file_value", prometheus.GaugeValue, float64(file.Value), file.Name)
+	}
+
+	for _, process := range keepalivedStats.TrackProcesses {
+		processUp := float64(0)
+		if process.Up {
+			processUp = 1
+		}
+
+		k.newConstMetric(ch, "keepalived_track_process_up", prometheus.GaugeValue, processUp, process.Name)
+		k.newConstMetric(ch, "keepalived_track_process_count", prometheus.GaugeValue, float64(process.Count), process.Name)
+	}
+
+	for _, group := range keepalivedStats.SyncGroups {
+		k.newConstMetric(ch, "keepalived_vrrp_sync_group_state", prometheus.GaugeValue, float64(group.State), group.Name)
+
+		for _, instance := range group.Instances {
+			k.newConstMetric(ch, "keepalived_vrrp_sync_group_member_info", prometheus.GaugeValue, 1, group.Name, instance)
+		}
+	}
+
 	for _, script := range keepalivedStats.Scripts {
 		if scriptStatus, ok := script.getIntStatus(); !ok {
 			slog.Warn("Unknown script status",
@@ -386,22 +816,306 @@ func (k *KeepalivedCollector) Collect(ch chan<- prometheus.Metric) {
 				k.newConstMetric(ch, "keepalived_script_state", prometheus.GaugeValue, float64(scriptState), script.Name)
 			}
 		}
+
+		k.newConstMetric(ch, "keepalived_script_info", prometheus.GaugeValue, 1, script.Name, script.Command)
+		k.newConstMetric(ch, "keepalived_script_interval_seconds", prometheus.GaugeValue, script.Interval, script.Name)
+		k.newConstMetric(ch, "keepalived_script_timeout_seconds", prometheus.GaugeValue, script.Timeout, script.Name)
+		k.newConstMetric(ch, "keepalived_script_weight", prometheus.GaugeValue, float64(script.Weight), script.Name)
+		k.newConstMetric(ch, "keepalived_script_rise", prometheus.GaugeValue, float64(script.Rise), script.Name)
+		k.newConstMetric(ch, "keepalived_script_fall", prometheus.GaugeValue, float64(script.Fall), script.Name)
+		k.newConstMetric(ch, "keepalived_script_result", prometheus.GaugeValue, float64(script.Result), script.Name)
+	}
+}
+
+func (k *KeepalivedCollector) collectGlobalDefinitions(ch chan<- prometheus.Metric, global *GlobalDefinitions) {
+	// global definitions are not dumped in JSON
+	if global == nil {
+		global = &GlobalDefinitions{}
+	}
+
+	k.newConstMetric(
+		ch,
+		"keepalived_info",
+		prometheus.GaugeValue,
+		1,
+		k.collector.KeepalivedVersion(),
+		global.RouterID,
+		global.InstanceName,
+		global.Namespace,
+		strconv.FormatBool(global.DynamicInterfaces),
+		strconv.FormatBool(global.ScriptSecurity),
+	)
+
+	// configuration is loaded at startup when keepalived has not been reloaded
+	configLoadTime := global.ConfigLoadTime
+	if configLoadTime == 0 {
+		configLoadTime = global.StartupTime
+	}
+
+	if configLoadTime > 0 {
+		k.newConstMetric(ch, "keepalived_config_load_timestamp_seconds", prometheus.GaugeValue, configLoadTime)
+	}
+}
+
+func (k *KeepalivedCollector) collectVIPs(ch chan<- prometheus.Metric, data VRRPData) {
+	vips := map[string][]VIP{"false": data.VIPs, "true": data.ExcludedVIPs}
+
+	for _, excluded := range []string{"false", "true"} {
+		for _, vip := range vips[excluded] {
+			// VIPs that failed to parse are only reported with their raw address
+			if vip.Address == "" {
+				continue
+			}
+
+			k.newConstMetric(
+				ch,
+				"keepalived_vrrp_vip_info",
+				prometheus.GaugeValue,
+				1,
+				data.IName,
+				vip.intf(data.Intf),
+				strconv.Itoa(data.VRID),
+				vip.ipAddress(),
+				strconv.Itoa(vip.Prefix),
+				vip.Family,
+				vip.Scope,
+				vip.Label,
+				strconv.FormatBool(vip.Set),
+				excluded,
+			)
+		}
+	}
+}
+
+func (k *KeepalivedCollector) collectTransport(ch chan<- prometheus.Metric, data VRRPData) {
+	// transport isn't part of the JSON dump
+	mode := data.transportMode()
+	if mode == "" {
+		return
+	}
+
+	k.newConstMetric(ch, "keepalived_vrrp_transport_info", prometheus.GaugeValue, 1, data.IName, mode, data.SrcIP, data.McastGroup)
+
+	for _, peer := range data.UnicastPeers {
+		k.newConstMetric(ch, "keepalived_vrrp_unicast_peer_info", prometheus.GaugeValue, 1, data.IName, peer)
+	}
+}
+
+// collectVIPPresence reports whether VIPs are configured on their interfaces and
+// whether it mismatches the instance state, like a MASTER without its VIP or a BACKUP still holding it.
+func (k *KeepalivedCollector) collectVIPPresence(ch chan<- prometheus.Metric, data VRRPData, addresses map[string][]string) {
+	master, _ := vrrpDataStringToIntState("MASTER")
+	backup, _ := vrrpDataStringToIntState("BACKUP")
+
+	for _, vip := range data.VIPs {
+		if vip.Address == "" {
+			continue
+		}
+
+		intf := vip.intf(data.Intf)
+		present := slices.Contains(addresses[intf], vip.Address)
+
+		presentValue := float64(0)
+		if present {
+			presentValue = 1
+		}
+
+		mismatch := float64(0)
+		if (data.State == master && !present) || (data.State == backup && present) {
+			mismatch = 1
+		}
+
+		k.newConstMetric(ch, "keepalived_vrrp_vip_present", prometheus.GaugeValue, presentValue, data.IName, vip.ipAddress(), intf)
+		k.newConstMetric(ch, "keepalived_vrrp_vip_mismatch", prometheus.GaugeValue, mismatch, data.IName, vip.ipAddress(), intf)
+	}
+}
+
+// collectVirtualRoutesPresence reports whether virtual routes and rules of the instance are in the kernel.
+func (k *KeepalivedCollector) collectVirtualRoutesPresence(ch chan<- prometheus.Metric, data VRRPData, tables *RoutingTables) {
+	// virtual routes and rules are only installed by a MASTER, so they'd always be missing on a BACKUP
+	if master, _ := vrrpDataStringToIntState("MASTER"); data.State != master {
+		return
+	}
+
+	// routes to the same destination and table, like ECMP nexthops or families of a default route,
+	// are exported as one series which is present only when all of them are found
+	type routeKey struct {
+		dst   string
+		table int
+	}
+
+	keys := make([]routeKey, 0, len(data.VirtualRoutes))
+	allFound := make(map[routeKey]bool, len(data.VirtualRoutes))
+
+	for _, route := range data.VirtualRoutes {
+		key := routeKey{dst: route.Dst, table: route.Table}
+
+		found := slices.ContainsFunc(tables.Routes, route.matches)
+		if previous, ok := allFound[key]; ok {
+			allFound[key] = previous && found
+
+			continue
+		}
+
+		keys = append(keys, key)
+		allFound[key] = found
+	}
+
+	for _, key := range keys {
+		present := float64(0)
+		if allFound[key] {
+			present = 1
+		}
+
+		k.newConstMetric(ch, "keepalived_vrrp_virtual_route_present", prometheus.GaugeValue, present, data.IName, key.dst, strconv.Itoa(key.table))
+	}
+
+	for _, rule := range data.VirtualRules {
+		present := float64(0)
+		if slices.ContainsFunc(tables.Rules, rule.matches) {
+			present = 1
+		}
+
+		k.newConstMetric(ch, "keepalived_vrrp_virtual_rule_present", prometheus.GaugeValue, present, data.IName, rule.From, rule.To, strconv.Itoa(rule.Table))
+	}
+}
+
+func (k *KeepalivedCollector) collectVirtualServers(ch chan<- prometheus.Metric, virtualServers []VirtualServer) {
+	for _, vs := range virtualServers {
+		quorumUp := float64(0)
+		if vs.QuorumUp {
+			quorumUp = 1
+		}
+
+		k.newConstMetric(ch, "keepalived_check_virtual_server_quorum", prometheus.GaugeValue, float64(vs.Quorum), vs.Name)
+		k.newConstMetric(ch, "keepalived_check_virtual_server_quorum_up", prometheus.GaugeValue, quorumUp, vs.Name)
+
+		for _, rs := range vs.RealServers {
+			k.newConstMetric(ch, "keepalived_check_real_server_weight", prometheus.GaugeValue, float64(rs.Weight), vs.Name, rs.Name)
+
+			// real servers without health checkers are reported with their alive status
+			if len(rs.Checkers) == 0 {
+				rsUp := float64(0)
+				if rs.Alive {
+					rsUp = 1
+				}
+
+				k.newConstMetric(ch, "keepalived_check_real_server_up", prometheus.GaugeValue, rsUp, vs.Name, rs.Name, "", "")
+			}
+
+			// checkers of the same type are told apart by their order within the real server
+			indexes := make(map[string]int)
+
+			for _, checker := range rs.Checkers {
+				checkerUp := float64(0)
+				if checker.Up {
+					checkerUp = 1
+				}
+
+				index := strconv.Itoa(indexes[checker.Type])
+				indexes[checker.Type]++
+
+				k.newConstMetric(ch, "keepalived_check_real_server_up", prometheus.GaugeValue, checkerUp,
+					vs.Name, rs.Name, checker.Type, index)
+			}
+		}
+	}
+}
+
+func (k *KeepalivedCollector) collectBFDs(ch chan<- prometheus.Metric, bfds []BFDInstance) {
+	for _, bfd := range bfds {
+		k.newConstMetric(ch, "keepalived_bfd_state", prometheus.GaugeValue, float64(bfd.LocalState), bfd.Name, bfd.Neighbor)
+		k.newConstMetric(
+			ch,
+			"keepalived_bfd_remote_state",
+			prometheus.GaugeValue,
+			float64(bfd.RemoteState),
+			bfd.Name,
+			bfd.Neighbor,
+		)
+		k.newConstMetric(
+			ch,
+			"keepalived_bfd_state_transitions_total",
+			prometheus.CounterValue,
+			float64(bfd.Transitions),
+			bfd.Name,
+			bfd.Neighbor,
+		)
+		k.newConstMetric(
+			ch,
+			"keepalived_bfd_info",
+			prometheus.GaugeValue,
+			1,
+			bfd.Name,
+			bfd.Neighbor,
+			strconv.FormatUint(uint64(bfd.LocalDiscriminator), 10),
+			strconv.FormatUint(uint64(bfd.RemoteDiscriminator), 10),
+		)
+	}
+}
+
+func (k *KeepalivedCollector) collectInstanceState(ch chan<- prometheus.Metric, data VRRPData) {
+	if !k.options.InstanceStateSet {
+		k.newConstMetric(
+			ch,
+			"keepalived_vrrp_instance_state",
+			prometheus.GaugeValue,
+			float64(data.State),
+			data.IName,
+			data.Intf,
+			strconv.Itoa(data.VRID),
+		)
+
+		return
+	}
+
+	for state, name := range VRRPStates {
+		value := float64(0)
+		if data.State == state {
+			value = 1
+		}
+
+		k.newConstMetric(
+			ch,
+			"keepalived_vrrp_instance_state",
+			prometheus.GaugeValue,
+			value,
+			data.IName,
+			data.Intf,
+			strconv.Itoa(data.VRID),
+			name,
+		)
 	}
 }
 
 func (k *KeepalivedCollector) getKeepalivedStats() (*KeepalivedStats, error) {
 	stats := &KeepalivedStats{
-		VRRPs:   make([]VRRP, 0),
-		Scripts: make([]VRRPScript, 0),
+		VRRPs:          make([]VRRP, 0),
+		Scripts:        make([]VRRPScript, 0),
+		SyncGroups:     make([]VRRPSyncGroup, 0),
+		TrackFiles:     make([]VRRPTrackFile, 0),
+		TrackProcesses: make([]VRRPTrackProcess, 0),
+		VirtualServers: make([]VirtualServer, 0),
+		BFDs:           make([]BFDInstance, 0),
 	}
 
 	var err error
 
-	if err := k.collector.Refresh(); err != nil {
+	// checker and BFD processes rewrite their own dumps on the DATA signal
+	var dumps []string
+	if k.options.Checker {
+		dumps = append(dumps, "keepalived_check.data")
+	}
+
+	if k.options.BFD {
+		dumps = append(dumps, "keepalived_bfd.data")
+	}
+
+	if err := k.collector.Refresh(dumps...); err != nil {
 		return nil, err
 	}
 
-	if k.useJSON {
+	if k.options.JSON {
 		stats.VRRPs, err = k.collector.JSONVrrps()
 		if err != nil {
 			return nil, err
@@ -410,11 +1124,45 @@ func (k *KeepalivedCollector) getKeepalivedStats() (*KeepalivedStats, error) {
 		return stats, nil
 	}
 
+	stats.Global, err = k.collector.GlobalDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
 	stats.Scripts, err = k.collector.ScriptVrrps()
 	if err != nil {
 		return nil, err
 	}
 
+	stats.SyncGroups, err = k.collector.SyncGroupVrrps()
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TrackFiles, err = k.collector.TrackFileVrrps()
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TrackProcesses, err = k.collector.TrackProcessVrrps()
+	if err != nil {
+		return nil, err
+	}
+
+	if k.options.Checker {
+		stats.VirtualServers, err = k.collector.CheckerVirtualServers()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if k.options.BFD {
+		stats.BFDs, err = k.collector.BFDInstances()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	vrrpStats, err := k.collector.StatsVrrps()
 	if err != nil {
 		return nil, err
@@ -455,7 +1203,7 @@ func (k *KeepalivedCollector) getKeepalivedStats() (*KeepalivedStats, error) {
 func (k *KeepalivedCollector) checkScript(vip string) bool {
 	var stdout, stderr bytes.Buffer
 
-	script := k.scriptPath + " " + vip
+	script := k.options.ScriptPath + " " + vip
 	cmd := exec.Command("/bin/sh", "-c", script)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -483,14 +1231,104 @@ func (k *KeepalivedCollector) Describe(ch chan<- *prometheus.Desc) {
 
 func (k *KeepalivedCollector) fillMetrics() {
 	commonLabels := []string{"iname", "intf", "vrid"}
+
+	instanceStateLabels := commonLabels
+	if k.options.InstanceStateSet {
+		instanceStateLabels = []string{"iname", "intf", "vrid", "state"}
+	}
+
 	k.metrics = map[string]*prometheus.Desc{
 		"keepalived_up": prometheus.NewDesc("keepalived_up", "Status", nil, nil),
+		"keepalived_exporter_snapshot_age_seconds": prometheus.NewDesc(
+			"keepalived_exporter_snapshot_age_seconds",
+			"Seconds since the exported keepalived data was refreshed",
+			nil,
+			nil,
+		),
+		"keepalived_exporter_refresh_duration_seconds": prometheus.NewDesc(
+			"keepalived_exporter_refresh_duration_seconds",
+			"Duration of the last keepalived data refresh in seconds",
+			nil,
+			nil,
+		),
+		"keepalived_exporter_stale_dump_total": prometheus.NewDesc(
+			"keepalived_exporter_stale_dump_total",
+			"Total refreshes where keepalived did not rewrite its dump files in time",
+			nil,
+			nil,
+		),
+		"keepalived_info": prometheus.NewDesc(
+			"keepalived_info",
+			"Keepalived version and global definitions",
+			[]string{"version", "router_id", "instance_name", "namespace", "dynamic_interfaces", "script_security"},
+			nil,
+		),
+		"keepalived_config_load_timestamp_seconds": prometheus.NewDesc(
+			"keepalived_config_load_timestamp_seconds",
+			"Timestamp of the last configuration load",
+			nil,
+			nil,
+		),
 		"keepalived_vrrp_state": prometheus.NewDesc(
 			"keepalived_vrrp_state",
 			"State of vrrp",
 			[]string{"iname", "intf", "vrid", "ip_address"},
 			nil,
 		),
+		"keepalived_vrrp_vip_info": prometheus.NewDesc(
+			"keepalived_vrrp_vip_info",
+			"Virtual IP address of vrrp and its prefix length, family, scope, label and set status",
+			[]string{"iname", "intf", "vrid", "ip_address", "prefix", "family", "scope", "label", "set", "excluded"},
+			nil,
+		),
+		"keepalived_vrrp_vip_present": prometheus.NewDesc(
+			"keepalived_vrrp_vip_present",
+			"Whether VIP is configured on its interface in the kernel",
+			[]string{"iname", "ip_address", "intf"},
+			nil,
+		),
+		"keepalived_vrrp_vip_mismatch": prometheus.NewDesc(
+			"keepalived_vrrp_vip_mismatch",
+			"Whether VIP presence mismatches vrrp state, a MASTER without VIP or a BACKUP holding it",
+			[]string{"iname", "ip_address", "intf"},
+			nil,
+		),
+		"keepalived_vrrp_transport_info": prometheus.NewDesc(
+			"keepalived_vrrp_transport_info",
+			"Transport mode of vrrp advertisements, its source address and multicast group",
+			[]string{"iname", "mode", "src_ip", "mcast_group"},
+			nil,
+		),
+		"keepalived_vrrp_unicast_peer_info": prometheus.NewDesc(
+			"keepalived_vrrp_unicast_peer_info",
+			"Unicast peer of vrrp",
+			[]string{"iname", "peer"},
+			nil,
+		),
+		"keepalived_vrrp_vmac_info": prometheus.NewDesc(
+			"keepalived_vrrp_vmac_info",
+			"VMAC or ipvlan interface of vrrp, its base interface and MAC address",
+			[]string{"iname", "base_intf", "vmac_intf", "mac"},
+			nil,
+		),
+		"keepalived_vrrp_virtual_route_present": prometheus.NewDesc(
+			"keepalived_vrrp_virtual_route_present",
+			"Whether virtual route of vrrp is in the kernel routing table",
+			[]string{"iname", "dst", "table"},
+			nil,
+		),
+		"keepalived_vrrp_virtual_rule_present": prometheus.NewDesc(
+			"keepalived_vrrp_virtual_rule_present",
+			"Whether virtual rule of vrrp is in the kernel routing rules",
+			[]string{"iname", "from", "to", "table"},
+			nil,
+		),
+		"keepalived_vrrp_instance_state": prometheus.NewDesc(
+			"keepalived_vrrp_instance_state",
+			"State of vrrp instance",
+			instanceStateLabels,
+			nil,
+		),
 		"keepalived_vrrp_excluded_state": prometheus.NewDesc(
 			"keepalived_vrrp_excluded_state",
 			"State of vrrp with excluded VIP",
@@ -509,6 +1347,96 @@ func (k *KeepalivedCollector) fillMetrics() {
 			commonLabels,
 			nil,
 		),
+		"keepalived_vrrp_priority": prometheus.NewDesc(
+			"keepalived_vrrp_priority",
+			"Configured priority of vrrp",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_effective_priority": prometheus.NewDesc(
+			"keepalived_vrrp_effective_priority",
+			"Effective priority of vrrp",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_total_priority": prometheus.NewDesc(
+			"keepalived_vrrp_total_priority",
+			"Total priority of vrrp including tracking weights",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_wantstate": prometheus.NewDesc(
+			"keepalived_vrrp_wantstate",
+			"Wanted state of vrrp",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_state_converged": prometheus.NewDesc(
+			"keepalived_vrrp_state_converged",
+			"Whether state of vrrp matches its wanted state",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_config_faults": prometheus.NewDesc(
+			"keepalived_vrrp_config_faults",
+			"Number of config faults of vrrp",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_track_faults": prometheus.NewDesc(
+			"keepalived_vrrp_track_faults",
+			"Number of interface and track script faults of vrrp",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_track_scripts_init": prometheus.NewDesc(
+			"keepalived_vrrp_track_scripts_init",
+			"Number of track scripts of vrrp in init state",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_advert_interval_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_advert_interval_seconds",
+			"Advertisement interval of vrrp in seconds",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_master_down_timer_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_master_down_timer_seconds",
+			"Master down timer of vrrp in seconds",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_down_timer_adverts": prometheus.NewDesc(
+			"keepalived_vrrp_down_timer_adverts",
+			"Number of missed advertisements before master is considered down",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_last_transition_timestamp_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_last_transition_timestamp_seconds",
+			"Timestamp of the last vrrp state transition",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_read_timeout_timestamp_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_read_timeout_timestamp_seconds",
+			"Timestamp of the next vrrp read timeout, when a master sends its next advert and a backup considers the master down",
+			commonLabels,
+			nil,
+		),
+		"keepalived_vrrp_tracked_interface_up": prometheus.NewDesc(
+			"keepalived_vrrp_tracked_interface_up",
+			"Status of interface tracked by vrrp and its weight",
+			[]string{"iname", "interface", "weight"},
+			nil,
+		),
+		"keepalived_vrrp_tracked_script_info": prometheus.NewDesc(
+			"keepalived_vrrp_tracked_script_info",
+			"Tracker Script tracked by vrrp and its weight",
+			[]string{"iname", "script", "weight"},
+			nil,
+		),
 		"keepalived_advertisements_received_total": prometheus.NewDesc(
 			"keepalived_advertisements_received_total",
 			"Advertisements received",
@@ -593,6 +1521,90 @@ func (k *KeepalivedCollector) fillMetrics() {
 			commonLabels,
 			nil,
 		),
+		"keepalived_vrrp_sync_group_state": prometheus.NewDesc(
+			"keepalived_vrrp_sync_group_state",
+			"State of vrrp sync group",
+			[]string{"group"},
+			nil,
+		),
+		"keepalived_vrrp_sync_group_member_info": prometheus.NewDesc(
+			"keepalived_vrrp_sync_group_member_info",
+			"Membership of vrrp in sync group",
+			[]string{"group", "iname"},
+			nil,
+		),
+		"keepalived_check_virtual_server_quorum": prometheus.NewDesc(
+			"keepalived_check_virtual_server_quorum",
+			"Minimum weight of alive real servers for virtual server to be up",
+			[]string{"vs"},
+			nil,
+		),
+		"keepalived_check_virtual_server_quorum_up": prometheus.NewDesc(
+			"keepalived_check_virtual_server_quorum_up",
+			"Whether virtual server quorum is met",
+			[]string{"vs"},
+			nil,
+		),
+		"keepalived_check_real_server_weight": prometheus.NewDesc(
+			"keepalived_check_real_server_weight",
+			"Weight of real server",
+			[]string{"vs", "rs"},
+			nil,
+		),
+		"keepalived_check_real_server_up": prometheus.NewDesc(
+			"keepalived_check_real_server_up",
+			"Status of real server health checker",
+			[]string{"vs", "rs", "checker", "checker_index"},
+			nil,
+		),
+		"keepalived_bfd_state": prometheus.NewDesc(
+			"keepalived_bfd_state",
+			"Local state of BFD session (0=AdminDown, 1=Down, 2=Init, 3=Up)",
+			[]string{"name", "neighbor"},
+			nil,
+		),
+		"keepalived_bfd_remote_state": prometheus.NewDesc(
+			"keepalived_bfd_remote_state",
+			"Remote state of BFD session (0=AdminDown, 1=Down, 2=Init, 3=Up)",
+			[]string{"name", "neighbor"},
+			nil,
+		),
+		"keepalived_bfd_state_transitions_total": prometheus.NewDesc(
+			"keepalived_bfd_state_transitions_total",
+			"Total state transitions of BFD session",
+			[]string{"name", "neighbor"},
+			nil,
+		),
+		"keepalived_bfd_info": prometheus.NewDesc(
+			"keepalived_bfd_info",
+			"BFD session local and remote discriminators",
+			[]string{"name", "neighbor", "local_discriminator", "remote_discriminator"},
+			nil,
+		),
+		"keepalived_vrrp_tracked_bfd_info": prometheus.NewDesc(
+			"keepalived_vrrp_tracked_bfd_info",
+			"BFD instance tracked by vrrp and its weight",
+			[]string{"iname", "bfd", "weight"},
+			nil,
+		),
+		"keepalived_track_file_value": prometheus.NewDesc(
+			"keepalived_track_file_value",
+			"Value read from track file",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_track_process_up": prometheus.NewDesc(
+			"keepalived_track_process_up",
+			"Whether tracked process has quorum of running processes",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_track_process_count": prometheus.NewDesc(
+			"keepalived_track_process_count",
+			"Number of running processes of tracked process",
+			[]string{"name"},
+			nil,
+		),
 		"keepalived_script_status": prometheus.NewDesc(
 			"keepalived_script_status",
 			"Tracker Script Status",
@@ -605,5 +1617,47 @@ func (k *KeepalivedCollector) fillMetrics() {
 			[]string{"name"},
 			nil,
 		),
+		"keepalived_script_info": prometheus.NewDesc(
+			"keepalived_script_info",
+			"Tracker Script Command",
+			[]string{"name", "command"},
+			nil,
+		),
+		"keepalived_script_interval_seconds": prometheus.NewDesc(
+			"keepalived_script_interval_seconds",
+			"Tracker Script Interval in seconds",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_timeout_seconds": prometheus.NewDesc(
+			"keepalived_script_timeout_seconds",
+			"Tracker Script Timeout in seconds",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_weight": prometheus.NewDesc(
+			"keepalived_script_weight",
+			"Tracker Script Weight",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_rise": prometheus.NewDesc(
+			"keepalived_script_rise",
+			"Tracker Script successes needed to become GOOD",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_fall": prometheus.NewDesc(
+			"keepalived_script_fall",
+			"Tracker Script failures needed to become BAD",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_result": prometheus.NewDesc(
+			"keepalived_script_result",
+			"Tracker Script consecutive result counter towards rise or fall",
+			[]string{"name"},
+			nil,
+		),
 	}
 }
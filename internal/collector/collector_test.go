@@ -1,11 +1,100 @@
 package collector
 
 import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
+// testCollector serves canned keepalived data, either JSON instances or a text fixture with empty stats.
+type testCollector struct {
+	Collector
+
+	mu         sync.Mutex
+	refreshes  atomic.Int32
+	delay      time.Duration
+	refreshErr error
+	dumps      []string
+
+	vrrps    []VRRP
+	dataPath string
+
+	services []IPVSService
+	ipvsErr  error
+}
+
+// testVRRPs is a single MASTER instance without VIPs.
+var testVRRPs = []VRRP{{Data: VRRPData{IName: "VI_1", Intf: "eth0", VRID: 51, State: 2}}}
+
+func (c *testCollector) Refresh(dumps ...string) error {
+	c.refreshes.Add(1)
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.dumps = dumps
+	c.mu.Unlock()
+
+	return c.refreshErr
+}
+
+func (c *testCollector) KeepalivedVersion() string {
+	return "2.2.8"
+}
+
+func (c *testCollector) HasVRRPScriptStateSupport() bool {
+	return true
+}
+
+func (c *testCollector) JSONVrrps() ([]VRRP, error) {
+	return c.vrrps, nil
+}
+
+func (c *testCollector) GlobalDefinitions() (*GlobalDefinitions, error) {
+	return ParseFile(c.dataPath, ParseGlobalDefinitions)
+}
+
+func (c *testCollector) ScriptVrrps() ([]VRRPScript, error) {
+	return nil, nil
+}
+
+func (c *testCollector) SyncGroupVrrps() ([]VRRPSyncGroup, error) {
+	return ParseFile(c.dataPath, ParseVRRPSyncGroups)
+}
+
+func (c *testCollector) TrackFileVrrps() ([]VRRPTrackFile, error) {
+	return nil, nil
+}
+
+func (c *testCollector) TrackProcessVrrps() ([]VRRPTrackProcess, error) {
+	return nil, nil
+}
+
+func (c *testCollector) DataVrrps() (map[string]*VRRPData, error) {
+	return ParseFile(c.dataPath, ParseVRRPData)
+}
+
+func (c *testCollector) StatsVrrps() (map[string]*VRRPStats, error) {
+	data, err := c.DataVrrps()
+
+	stats := make(map[string]*VRRPStats, len(data))
+	for instance := range data {
+		stats[instance] = &VRRPStats{}
+	}
+
+	return stats, err
+}
+
+func (c *testCollector) IPVSServices() ([]IPVSService, error) {
+	return c.services, c.ipvsErr
+}
+
 func TestNewConstMetric(t *testing.T) {
 	t.Parallel()
 
@@ -35,13 +124,102 @@ func TestNewConstMetric(t *testing.T) {
 			"keepalived_priority_zero_sent_total",
 			"keepalived_gratuitous_arp_delay_total":
 			valueType = prometheus.CounterValue
-		case "keepalived_up":
+		case "keepalived_vrrp_priority",
+			"keepalived_vrrp_effective_priority",
+			"keepalived_vrrp_total_priority",
+			"keepalived_vrrp_instance_state",
+			"keepalived_vrrp_wantstate",
+			"keepalived_vrrp_state_converged",
+			"keepalived_vrrp_config_faults",
+			"keepalived_vrrp_track_faults",
+			"keepalived_vrrp_track_scripts_init",
+			"keepalived_vrrp_advert_interval_seconds",
+			"keepalived_vrrp_master_down_timer_seconds",
+			"keepalived_vrrp_down_timer_adverts",
+			"keepalived_vrrp_last_transition_timestamp_seconds",
+			"keepalived_vrrp_read_timeout_timestamp_seconds":
 			valueType = prometheus.GaugeValue
+		case "keepalived_up", "keepalived_config_load_timestamp_seconds",
+			"keepalived_exporter_snapshot_age_seconds", "keepalived_exporter_refresh_duration_seconds":
+			valueType = prometheus.GaugeValue
+			labelValues = nil
+		case "keepalived_exporter_stale_dump_total":
+			valueType = prometheus.CounterValue
 			labelValues = nil
+		case "keepalived_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"version", "router_id", "instance_name", "namespace", "dynamic_interfaces", "script_security"}
 		case "keepalived_vrrp_state", "keepalived_vrrp_excluded_state", "keepalived_exporter_check_script_status":
 			valueType = prometheus.GaugeValue
 			labelValues = []string{"iname", "intf", "vrid", "ip_address"}
-		case "keepalived_script_status", "keepalived_script_state":
+		case "keepalived_vrrp_vip_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "intf", "vrid", "ip_address", "prefix", "family", "scope", "label", "set", "excluded"}
+		case "keepalived_vrrp_vip_present", "keepalived_vrrp_vip_mismatch":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "ip_address", "intf"}
+		case "keepalived_vrrp_transport_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "mode", "src_ip", "mcast_group"}
+		case "keepalived_vrrp_unicast_peer_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "peer"}
+		case "keepalived_vrrp_vmac_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "base_intf", "vmac_intf", "mac"}
+		case "keepalived_vrrp_virtual_route_present":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "dst", "table"}
+		case "keepalived_vrrp_virtual_rule_present":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "from", "to", "table"}
+		case "keepalived_vrrp_tracked_interface_up":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "interface", "weight"}
+		case "keepalived_vrrp_tracked_script_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "script", "weight"}
+		case "keepalived_vrrp_sync_group_state":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"group"}
+		case "keepalived_vrrp_sync_group_member_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"group", "iname"}
+		case "keepalived_check_virtual_server_quorum", "keepalived_check_virtual_server_quorum_up":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"vs"}
+		case "keepalived_check_real_server_weight":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"vs", "rs"}
+		case "keepalived_check_real_server_up":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"vs", "rs", "checker", "checker_index"}
+		case "keepalived_bfd_state", "keepalived_bfd_remote_state":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"name", "neighbor"}
+		case "keepalived_bfd_state_transitions_total":
+			valueType = prometheus.CounterValue
+			labelValues = []string{"name", "neighbor"}
+		case "keepalived_bfd_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"name", "neighbor", "local_discriminator", "remote_discriminator"}
+		case "keepalived_vrrp_tracked_bfd_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"iname", "bfd", "weight"}
+		case "keepalived_track_file_value", "keepalived_track_process_up", "keepalived_track_process_count":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"name"}
+		case "keepalived_script_info":
+			valueType = prometheus.GaugeValue
+			labelValues = []string{"name", "command"}
+		case "keepalived_script_status",
+			"keepalived_script_state",
+			"keepalived_script_interval_seconds",
+			"keepalived_script_timeout_seconds",
+			"keepalived_script_weight",
+			"keepalived_script_rise",
+			"keepalived_script_fall",
+			"keepalived_script_result":
 			valueType = prometheus.GaugeValue
 			labelValues = []string{"name"}
 		default:
@@ -69,12 +247,96 @@ func TestFillMetrics(t *testing.T) {
 
 	excpectedMetrics := map[string]*prometheus.Desc{
 		"keepalived_up": prometheus.NewDesc("keepalived_up", "Status", nil, nil),
+		"keepalived_exporter_snapshot_age_seconds": prometheus.NewDesc(
+			"keepalived_exporter_snapshot_age_seconds",
+			"Seconds since the exported keepalived data was refreshed",
+			nil,
+			nil,
+		),
+		"keepalived_exporter_refresh_duration_seconds": prometheus.NewDesc(
+			"keepalived_exporter_refresh_duration_seconds",
+			"Duration of the last keepalived data refresh in seconds",
+			nil,
+			nil,
+		),
+		"keepalived_exporter_stale_dump_total": prometheus.NewDesc(
+			"keepalived_exporter_stale_dump_total",
+			"Total refreshes where keepalived did not rewrite its dump files in time",
+			nil,
+			nil,
+		),
+		"keepalived_info": prometheus.NewDesc(
+			"keepalived_info",
+			"Keepalived version and global definitions",
+			[]string{"version", "router_id", "instance_name", "namespace", "dynamic_interfaces", "script_security"},
+			nil,
+		),
+		"keepalived_config_load_timestamp_seconds": prometheus.NewDesc(
+			"keepalived_config_load_timestamp_seconds",
+			"Timestamp of the last configuration load",
+			nil,
+			nil,
+		),
 		"keepalived_vrrp_state": prometheus.NewDesc(
 			"keepalived_vrrp_state",
 			"State of vrrp",
 			[]string{"iname", "intf", "vrid", "ip_address"},
 			nil,
 		),
+		"keepalived_vrrp_vip_info": prometheus.NewDesc(
+			"keepalived_vrrp_vip_info",
+			"Virtual IP address of vrrp and its prefix length, family, scope, label and set status",
+			[]string{"iname", "intf", "vrid", "ip_address", "prefix", "family", "scope", "label", "set", "excluded"},
+			nil,
+		),
+		"keepalived_vrrp_vip_present": prometheus.NewDesc(
+			"keepalived_vrrp_vip_present",
+			"Whether VIP is configured on its interface in the kernel",
+			[]string{"iname", "ip_address", "intf"},
+			nil,
+		),
+		"keepalived_vrrp_vip_mismatch": prometheus.NewDesc(
+			"keepalived_vrrp_vip_mismatch",
+			"Whether VIP presence mismatches vrrp state, a MASTER without VIP or a BACKUP holding it",
+			[]string{"iname", "ip_address", "intf"},
+			nil,
+		),
+		"keepalived_vrrp_transport_info": prometheus.NewDesc(
+			"keepalived_vrrp_transport_info",
+			"Transport mode of vrrp advertisements, its source address and multicast group",
+			[]string{"iname", "mode", "src_ip", "mcast_group"},
+			nil,
+		),
+		"keepalived_vrrp_unicast_peer_info": prometheus.NewDesc(
+			"keepalived_vrrp_unicast_peer_info",
+			"Unicast peer of vrrp",
+			[]string{"iname", "peer"},
+			nil,
+		),
+		"keepalived_vrrp_vmac_info": prometheus.NewDesc(
+			"keepalived_vrrp_vmac_info",
+			"VMAC or ipvlan interface of vrrp, its base interface and MAC address",
+			[]string{"iname", "base_intf", "vmac_intf", "mac"},
+			nil,
+		),
+		"keepalived_vrrp_virtual_route_present": prometheus.NewDesc(
+			"keepalived_vrrp_virtual_route_present",
+			"Whether virtual route of vrrp is in the kernel routing table",
+			[]string{"iname", "dst", "table"},
+			nil,
+		),
+		"keepalived_vrrp_virtual_rule_present": prometheus.NewDesc(
+			"keepalived_vrrp_virtual_rule_present",
+			"Whether virtual rule of vrrp is in the kernel routing rules",
+			[]string{"iname", "from", "to", "table"},
+			nil,
+		),
+		"keepalived_vrrp_instance_state": prometheus.NewDesc(
+			"keepalived_vrrp_instance_state",
+			"State of vrrp instance",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
 		"keepalived_vrrp_excluded_state": prometheus.NewDesc(
 			"keepalived_vrrp_excluded_state",
 			"State of vrrp with excluded VIP",
@@ -93,6 +355,84 @@ func TestFillMetrics(t *testing.T) {
 			[]string{"iname", "intf", "vrid"},
 			nil,
 		),
+		"keepalived_vrrp_priority": prometheus.NewDesc(
+			"keepalived_vrrp_priority",
+			"Configured priority of vrrp",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_effective_priority": prometheus.NewDesc(
+			"keepalived_vrrp_effective_priority",
+			"Effective priority of vrrp",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_total_priority": prometheus.NewDesc(
+			"keepalived_vrrp_total_priority",
+			"Total priority of vrrp including tracking weights",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_wantstate": prometheus.NewDesc(
+			"keepalived_vrrp_wantstate",
+			"Wanted state of vrrp",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_state_converged": prometheus.NewDesc(
+			"keepalived_vrrp_state_converged",
+			"Whether state of vrrp matches its wanted state",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_config_faults": prometheus.NewDesc(
+			"keepalived_vrrp_config_faults",
+			"Number of config faults of vrrp",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_track_faults": prometheus.NewDesc(
+			"keepalived_vrrp_track_faults",
+			"Number of interface and track script faults of vrrp",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_track_scripts_init": prometheus.NewDesc(
+			"keepalived_vrrp_track_scripts_init",
+			"Number of track scripts of vrrp in init state",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_advert_interval_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_advert_interval_seconds",
+			"Advertisement interval of vrrp in seconds",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_master_down_timer_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_master_down_timer_seconds",
+			"Master down timer of vrrp in seconds",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_down_timer_adverts": prometheus.NewDesc(
+			"keepalived_vrrp_down_timer_adverts",
+			"Number of missed advertisements before master is considered down",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_last_transition_timestamp_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_last_transition_timestamp_seconds",
+			"Timestamp of the last vrrp state transition",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
+		"keepalived_vrrp_read_timeout_timestamp_seconds": prometheus.NewDesc(
+			"keepalived_vrrp_read_timeout_timestamp_seconds",
+			"Timestamp of the next vrrp read timeout, when a master sends its next advert and a backup considers the master down",
+			[]string{"iname", "intf", "vrid"},
+			nil,
+		),
 		"keepalived_advertisements_received_total": prometheus.NewDesc(
 			"keepalived_advertisements_received_total",
 			"Advertisements received",
@@ -177,6 +517,102 @@ func TestFillMetrics(t *testing.T) {
 			[]string{"iname", "intf", "vrid"},
 			nil,
 		),
+		"keepalived_vrrp_tracked_interface_up": prometheus.NewDesc(
+			"keepalived_vrrp_tracked_interface_up",
+			"Status of interface tracked by vrrp and its weight",
+			[]string{"iname", "interface", "weight"},
+			nil,
+		),
+		"keepalived_vrrp_tracked_script_info": prometheus.NewDesc(
+			"keepalived_vrrp_tracked_script_info",
+			"Tracker Script tracked by vrrp and its weight",
+			[]string{"iname", "script", "weight"},
+			nil,
+		),
+		"keepalived_vrrp_sync_group_state": prometheus.NewDesc(
+			"keepalived_vrrp_sync_group_state",
+			"State of vrrp sync group",
+			[]string{"group"},
+			nil,
+		),
+		"keepalived_vrrp_sync_group_member_info": prometheus.NewDesc(
+			"keepalived_vrrp_sync_group_member_info",
+			"Membership of vrrp in sync group",
+			[]string{"group", "iname"},
+			nil,
+		),
+		"keepalived_check_virtual_server_quorum": prometheus.NewDesc(
+			"keepalived_check_virtual_server_quorum",
+			"Minimum weight of alive real servers for virtual server to be up",
+			[]string{"vs"},
+			nil,
+		),
+		"keepalived_check_virtual_server_quorum_up": prometheus.NewDesc(
+			"keepalived_check_virtual_server_quorum_up",
+			"Whether virtual server quorum is met",
+			[]string{"vs"},
+			nil,
+		),
+		"keepalived_check_real_server_weight": prometheus.NewDesc(
+			"keepalived_check_real_server_weight",
+			"Weight of real server",
+			[]string{"vs", "rs"},
+			nil,
+		),
+		"keepalived_check_real_server_up": prometheus.NewDesc(
+			"keepalived_check_real_server_up",
+			"Status of real server health checker",
+			[]string{"vs", "rs", "checker", "checker_index"},
+			nil,
+		),
+		"keepalived_bfd_state": prometheus.NewDesc(
+			"keepalived_bfd_state",
+			"Local state of BFD session (0=AdminDown, 1=Down, 2=Init, 3=Up)",
+			[]string{"name", "neighbor"},
+			nil,
+		),
+		"keepalived_bfd_remote_state": prometheus.NewDesc(
+			"keepalived_bfd_remote_state",
+			"Remote state of BFD session (0=AdminDown, 1=Down, 2=Init, 3=Up)",
+			[]string{"name", "neighbor"},
+			nil,
+		),
+		"keepalived_bfd_state_transitions_total": prometheus.NewDesc(
+			"keepalived_bfd_state_transitions_total",
+			"Total state transitions of BFD session",
+			[]string{"name", "neighbor"},
+			nil,
+		),
+		"keepalived_bfd_info": prometheus.NewDesc(
+			"keepalived_bfd_info",
+			"BFD session local and remote discriminators",
+			[]string{"name", "neighbor", "local_discriminator", "remote_discriminator"},
+			nil,
+		),
+		"keepalived_vrrp_tracked_bfd_info": prometheus.NewDesc(
+			"keepalived_vrrp_tracked_bfd_info",
+			"BFD instance tracked by vrrp and its weight",
+			[]string{"iname", "bfd", "weight"},
+			nil,
+		),
+		"keepalived_track_file_value": prometheus.NewDesc(
+			"keepalived_track_file_value",
+			"Value read from track file",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_track_process_up": prometheus.NewDesc(
+			"keepalived_track_process_up",
+			"Whether tracked process has quorum of running processes",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_track_process_count": prometheus.NewDesc(
+			"keepalived_track_process_count",
+			"Number of running processes of tracked process",
+			[]string{"name"},
+			nil,
+		),
 		"keepalived_script_status": prometheus.NewDesc(
 			"keepalived_script_status",
 			"Tracker Script Status",
@@ -189,6 +625,48 @@ func TestFillMetrics(t *testing.T) {
 			[]string{"name"},
 			nil,
 		),
+		"keepalived_script_info": prometheus.NewDesc(
+			"keepalived_script_info",
+			"Tracker Script Command",
+			[]string{"name", "command"},
+			nil,
+		),
+		"keepalived_script_interval_seconds": prometheus.NewDesc(
+			"keepalived_script_interval_seconds",
+			"Tracker Script Interval in seconds",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_timeout_seconds": prometheus.NewDesc(
+			"keepalived_script_timeout_seconds",
+			"Tracker Script Timeout in seconds",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_weight": prometheus.NewDesc(
+			"keepalived_script_weight",
+			"Tracker Script Weight",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_rise": prometheus.NewDesc(
+			"keepalived_script_rise",
+			"Tracker Script successes needed to become GOOD",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_fall": prometheus.NewDesc(
+			"keepalived_script_fall",
+			"Tracker Script failures needed to become BAD",
+			[]string{"name"},
+			nil,
+		),
+		"keepalived_script_result": prometheus.NewDesc(
+			"keepalived_script_result",
+			"Tracker Script consecutive result counter towards rise or fall",
+			[]string{"name"},
+			nil,
+		),
 	}
 
 	if len(k.metrics) != len(excpectedMetrics) {
@@ -205,3 +683,615 @@ func TestFillMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestCollectInstanceState(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{IName: "VI_1", Intf: "eth0", VRID: 51, State: 2}
+
+	k := &KeepalivedCollector{}
+	k.fillMetrics()
+
+	ch := make(chan prometheus.Metric, len(VRRPStates))
+	k.collectInstanceState(ch, data)
+	close(ch)
+
+	if len(ch) != 1 {
+		t.Fail()
+	}
+
+	k = &KeepalivedCollector{options: Options{InstanceStateSet: true}}
+	k.fillMetrics()
+
+	ch = make(chan prometheus.Metric, len(VRRPStates))
+	k.collectInstanceState(ch, data)
+	close(ch)
+
+	if len(ch) != len(VRRPStates) {
+		t.Fail()
+	}
+
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		var state string
+
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "state" {
+				state = label.GetValue()
+			}
+		}
+
+		expected := float64(0)
+		if state == "MASTER" {
+			expected = 1
+		}
+
+		if metric.GetGauge().GetValue() != expected {
+			t.Fail()
+		}
+	}
+}
+
+func TestCollectVirtualServers(t *testing.T) {
+	t.Parallel()
+
+	virtualServers := []VirtualServer{
+		{
+			Name:     "[10.0.0.100]:tcp:80",
+			Quorum:   1,
+			QuorumUp: true,
+			RealServers: []RealServer{
+				{Name: "[192.168.1.11]:tcp:80", Weight: 1, Alive: true},
+				{
+					Name:   "[192.168.1.12]:tcp:80",
+					Weight: 1,
+					Checkers: []RealServerChecker{
+						{Type: "HTTP_GET", Up: false},
+						{Type: "MISC_CHECK", Up: true},
+						{Type: "HTTP_GET", Up: true},
+					},
+				},
+			},
+		},
+	}
+
+	k := &KeepalivedCollector{}
+	k.fillMetrics()
+
+	ch := make(chan prometheus.Metric, 10)
+	k.collectVirtualServers(ch, virtualServers)
+	close(ch)
+
+	// quorum, quorum_up, two weights and four checker statuses
+	if len(ch) != 8 {
+		t.Fail()
+	}
+
+	up := make(map[string]float64)
+
+	for m := range ch {
+		if m.Desc() != k.metrics["keepalived_check_real_server_up"] {
+			continue
+		}
+
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		var rs, checker, index string
+
+		for _, label := range metric.GetLabel() {
+			switch label.GetName() {
+			case "rs":
+				rs = label.GetValue()
+			case "checker":
+				checker = label.GetValue()
+			case "checker_index":
+				index = label.GetValue()
+			}
+		}
+
+		up[rs+"/"+checker+"/"+index] = metric.GetGauge().GetValue()
+	}
+
+	expected := map[string]float64{
+		"[192.168.1.11]:tcp:80//":            1,
+		"[192.168.1.12]:tcp:80/HTTP_GET/0":   0,
+		"[192.168.1.12]:tcp:80/MISC_CHECK/0": 1,
+		"[192.168.1.12]:tcp:80/HTTP_GET/1":   1,
+	}
+	if !reflect.DeepEqual(up, expected) {
+		t.Fail()
+	}
+}
+
+func TestCollectBFDs(t *testing.T) {
+	t.Parallel()
+
+	bfds := []BFDInstance{
+		{Name: "bfd_gw", Neighbor: "10.0.0.1", LocalState: 3, RemoteState: 3, LocalDiscriminator: 439041101, Transitions: 3},
+		{Name: "bfd_peer", Neighbor: "fd00::12", LocalState: 1},
+	}
+
+	k := &KeepalivedCollector{}
+	k.fillMetrics()
+
+	ch := make(chan prometheus.Metric, 10)
+	k.collectBFDs(ch, bfds)
+	close(ch)
+
+	// state, remote state, transitions and info of each session
+	if len(ch) != 8 {
+		t.Fail()
+	}
+
+	states := make(map[string]float64)
+
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		switch m.Desc() {
+		case k.metrics["keepalived_bfd_state"]:
+			states[metric.GetLabel()[0].GetValue()] = metric.GetGauge().GetValue()
+		case k.metrics["keepalived_bfd_state_transitions_total"]:
+			if metric.GetLabel()[0].GetValue() == "bfd_gw" && metric.GetCounter().GetValue() != 3 {
+				t.Fail()
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(states, map[string]float64{"bfd_gw": 3, "bfd_peer": 1}) {
+		t.Fail()
+	}
+}
+
+func TestCollectVIPs(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{
+		IName:        "VI_1",
+		Intf:         "eth0",
+		VRID:         51,
+		VIPs:         []VIP{{Address: "fd00::100", Prefix: 64, Family: "ipv6", Device: "eth1", Scope: "global", Set: true}},
+		ExcludedVIPs: []VIP{{Address: "10.0.0.1", Prefix: 32, Family: "ipv4"}},
+	}
+
+	k := &KeepalivedCollector{}
+	k.fillMetrics()
+
+	ch := make(chan prometheus.Metric, 2)
+	k.collectVIPs(ch, data)
+	close(ch)
+
+	expected := []map[string]string{
+		{
+			"iname": "VI_1", "intf": "eth1", "vrid": "51", "ip_address": "fd00::100/64", "prefix": "64",
+			"family": "ipv6", "scope": "global", "label": "", "set": "true", "excluded": "false",
+		},
+		{
+			"iname": "VI_1", "intf": "eth0", "vrid": "51", "ip_address": "10.0.0.1", "prefix": "32",
+			"family": "ipv4", "scope": "", "label": "", "set": "false", "excluded": "true",
+		},
+	}
+
+	labels := make([]map[string]string, 0)
+
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		l := make(map[string]string)
+		for _, label := range metric.GetLabel() {
+			l[label.GetName()] = label.GetValue()
+		}
+
+		labels = append(labels, l)
+	}
+
+	if !reflect.DeepEqual(labels, expected) {
+		t.Fail()
+	}
+}
+
+func TestCollectVIPPresence(t *testing.T) {
+	t.Parallel()
+
+	vips := []VIP{
+		{Address: "10.0.0.100", Prefix: 24, Family: "ipv4", Device: "eth0"},
+		{Address: "fd00::100", Prefix: 64, Family: "ipv6"},
+	}
+	addresses := map[string][]string{"eth0": {"10.0.0.10", "10.0.0.100"}, "eth1": {"fd00::100"}}
+
+	testCases := []struct {
+		state    int
+		expected map[string][2]float64
+	}{
+		{state: 2, expected: map[string][2]float64{"10.0.0.100/24": {1, 0}, "fd00::100/64": {0, 1}}},
+		{state: 1, expected: map[string][2]float64{"10.0.0.100/24": {1, 1}, "fd00::100/64": {0, 0}}},
+		{state: 3, expected: map[string][2]float64{"10.0.0.100/24": {1, 0}, "fd00::100/64": {0, 0}}},
+	}
+
+	k := &KeepalivedCollector{}
+	k.fillMetrics()
+
+	for _, tc := range testCases {
+		data := VRRPData{IName: "VI_1", Intf: "eth2", State: tc.state, VIPs: vips}
+
+		ch := make(chan prometheus.Metric, 4)
+		k.collectVIPPresence(ch, data, addresses)
+		close(ch)
+
+		got := make(map[string][2]float64)
+
+		for m := range ch {
+			metric := &dto.Metric{}
+			if err := m.Write(metric); err != nil {
+				t.Fatal(err)
+			}
+
+			var ipAddress string
+
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "ip_address" {
+					ipAddress = label.GetValue()
+				}
+			}
+
+			values := got[ipAddress]
+			if m.Desc() == k.metrics["keepalived_vrrp_vip_mismatch"] {
+				values[1] = metric.GetGauge().GetValue()
+			} else {
+				values[0] = metric.GetGauge().GetValue()
+			}
+
+			got[ipAddress] = values
+		}
+
+		if !reflect.DeepEqual(got, tc.expected) {
+			t.Log(tc.state, got)
+			t.Fail()
+		}
+	}
+}
+
+func TestCollectTransport(t *testing.T) {
+	t.Parallel()
+
+	k := &KeepalivedCollector{}
+	k.fillMetrics()
+
+	testCases := []struct {
+		data     VRRPData
+		expected int
+	}{
+		{data: VRRPData{IName: "VI_1"}, expected: 0},
+		{data: VRRPData{IName: "VI_1", SrcIP: "10.0.0.11", McastGroup: "224.0.0.18"}, expected: 1},
+		{data: VRRPData{IName: "VI_2", SrcIP: "fd00::11", UnicastPeers: []string{"fd00::12", "fd00::13"}}, expected: 3},
+	}
+
+	for _, tc := range testCases {
+		ch := make(chan prometheus.Metric, 3)
+		k.collectTransport(ch, tc.data)
+		close(ch)
+
+		if len(ch) != tc.expected {
+			t.Fail()
+		}
+	}
+}
+
+func TestCollectVirtualRoutesPresence(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{
+		IName: "VI_1",
+		State: 2,
+		VirtualRoutes: []VirtualRoute{
+			{Dst: "192.168.100.0/24", Family: "ipv4", Table: 100},
+			{Dst: "default", Family: "ipv4", Via: "10.0.0.1", Table: 254},
+			{Dst: "default", Family: "ipv4", Via: "10.0.0.2", Table: 254},
+			{Dst: "default", Family: "ipv4", Via: "10.0.0.1", Table: 200},
+			{Dst: "default", Family: "ipv6", Via: "fd00::1", Table: 200},
+			{Dst: "fd00:1::/64", Family: "ipv6", Metric: 100},
+			{Dst: "fd00:2::/64", Family: "ipv6", Metric: 100},
+		},
+		VirtualRules: []VirtualRule{{From: "10.0.0.100/32", To: "all", Table: 100}},
+	}
+	// ipv4 default route is ECMP and listed once per nexthop, ipv6 default route is missing
+	tables := &RoutingTables{
+		Routes: []VirtualRoute{
+			{Dst: "192.168.100.0/24", Family: "ipv4", Table: 254},
+			{Dst: "default", Family: "ipv4", Via: "10.0.0.1", Table: 254},
+			{Dst: "default", Family: "ipv4", Via: "10.0.0.2", Table: 254},
+			{Dst: "default", Family: "ipv4", Via: "10.0.0.1", Table: 200},
+			{Dst: "fd00:1::/64", Family: "ipv6", Metric: 100, Table: 254},
+			{Dst: "fd00:2::/64", Family: "ipv6", Metric: 1024, Table: 254},
+		},
+		Rules: []VirtualRule{{From: "10.0.0.100/32", To: "all", Table: 100}},
+	}
+
+	k := &KeepalivedCollector{}
+	k.fillMetrics()
+
+	collect := func(data VRRPData) map[string]float64 {
+		ch := make(chan prometheus.Metric, 10)
+		k.collectVirtualRoutesPresence(ch, data, tables)
+		close(ch)
+
+		values := make(map[string]float64)
+
+		for m := range ch {
+			metric := &dto.Metric{}
+			if err := m.Write(metric); err != nil {
+				t.Fatal(err)
+			}
+
+			labels := make([]string, 0, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != "iname" {
+					labels = append(labels, label.GetValue())
+				}
+			}
+
+			values[strings.Join(labels, " ")] = metric.GetGauge().GetValue()
+		}
+
+		return values
+	}
+
+	// labels are sorted by name: dst and table for routes, from, table and to for rules
+	expected := map[string]float64{
+		"192.168.100.0/24 100":  0,
+		"default 254":           1,
+		"default 200":           0,
+		"fd00:1::/64 0":         1,
+		"fd00:2::/64 0":         0,
+		"10.0.0.100/32 100 all": 1,
+	}
+	if values := collect(data); !reflect.DeepEqual(values, expected) {
+		t.Log(values)
+		t.Fail()
+	}
+
+	// a BACKUP doesn't install its virtual routes and rules
+	data.State = 1
+	if values := collect(data); len(values) != 0 {
+		t.Log(values)
+		t.Fail()
+	}
+}
+
+func TestCollectVRRPFaults(t *testing.T) {
+	t.Parallel()
+
+	k := NewKeepalivedCollector(Options{}, &testCollector{dataPath: "../../test_files/v2.2.8/keepalived_fault.data"})
+
+	ch := make(chan prometheus.Metric, 200)
+	k.Collect(ch)
+	close(ch)
+
+	// values of instance gauges by metric and instance name
+	values := make(map[string]map[string]float64)
+
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, desc := range k.metrics {
+			if desc != m.Desc() {
+				continue
+			}
+
+			for _, label := range metric.GetLabel() {
+				if label.GetName() != "iname" {
+					continue
+				}
+
+				if values[name] == nil {
+					values[name] = make(map[string]float64)
+				}
+
+				values[name][label.GetValue()] = metric.GetGauge().GetValue()
+			}
+		}
+	}
+
+	// VI_FAULT holds its sync group in FAULT while VI_PEER is already BACKUP
+	expected := map[string]map[string]float64{
+		"keepalived_vrrp_wantstate":                         {"VI_FAULT": 1, "VI_PEER": 1},
+		"keepalived_vrrp_state_converged":                   {"VI_FAULT": 0, "VI_PEER": 1},
+		"keepalived_vrrp_config_faults":                     {"VI_FAULT": 1, "VI_PEER": 0},
+		"keepalived_vrrp_track_faults":                      {"VI_FAULT": 2, "VI_PEER": 0},
+		"keepalived_vrrp_track_scripts_init":                {"VI_FAULT": 1, "VI_PEER": 0},
+		"keepalived_vrrp_advert_interval_seconds":           {"VI_FAULT": 4, "VI_PEER": 4},
+		"keepalived_vrrp_master_down_timer_seconds":         {"VI_FAULT": 12.15625, "VI_PEER": 12.15625},
+		"keepalived_vrrp_down_timer_adverts":                {"VI_FAULT": 3, "VI_PEER": 3},
+		"keepalived_vrrp_last_transition_timestamp_seconds": {"VI_FAULT": 1700570000.25, "VI_PEER": 1700569000.125},
+		"keepalived_vrrp_read_timeout_timestamp_seconds":    {"VI_FAULT": 1700656400.45, "VI_PEER": 1700655400.325},
+	}
+
+	for name, instances := range expected {
+		if !reflect.DeepEqual(values[name], instances) {
+			t.Log(name, values[name])
+			t.Fail()
+		}
+	}
+}
+
+func TestCollectJSONSkipsTextOnlyMetrics(t *testing.T) {
+	t.Parallel()
+
+	c := &testCollector{vrrps: testVRRPs}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+
+	ch := make(chan prometheus.Metric, 100)
+	k.Collect(ch)
+	close(ch)
+
+	var effectivePriority bool
+
+	for m := range ch {
+		switch m.Desc() {
+		case k.metrics["keepalived_vrrp_total_priority"],
+			k.metrics["keepalived_vrrp_config_faults"],
+			k.metrics["keepalived_vrrp_track_faults"],
+			k.metrics["keepalived_vrrp_track_scripts_init"]:
+			t.Log(m.Desc())
+			t.Fail()
+		case k.metrics["keepalived_vrrp_effective_priority"]:
+			effectivePriority = true
+		}
+	}
+
+	if !effectivePriority {
+		t.Fail()
+	}
+}
+
+func TestCollectRawVIPs(t *testing.T) {
+	t.Parallel()
+
+	k := NewKeepalivedCollector(Options{JSON: true}, &testCollector{vrrps: []VRRP{{Data: VRRPData{
+		IName: "VI_1",
+		Intf:  "eth0",
+		VRID:  51,
+		State: 2,
+		VIPs: []VIP{
+			{Raw: "10.32.75.200/32", Address: "10.32.75.200", Prefix: 32, Family: "ipv4", Device: "eth0"},
+			{Raw: "10.32.75.300/32"},
+		},
+	}}}})
+
+	ch := make(chan prometheus.Metric, 100)
+	k.Collect(ch)
+	close(ch)
+
+	ipAddresses := make(map[string][]string)
+
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, desc := range k.metrics {
+			if desc != m.Desc() {
+				continue
+			}
+
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "ip_address" {
+					ipAddresses[name] = append(ipAddresses[name], label.GetValue())
+				}
+			}
+		}
+	}
+
+	// vrrp_state keeps the address as dumped, VIPs failing to parse are only left out of the parsed series
+	expected := map[string][]string{
+		"keepalived_vrrp_state":    {"10.32.75.200/32", "10.32.75.300/32"},
+		"keepalived_vrrp_vip_info": {"10.32.75.200"},
+	}
+	if !reflect.DeepEqual(ipAddresses, expected) {
+		t.Log(ipAddresses)
+		t.Fail()
+	}
+}
+
+func TestCollectStateWithoutVIPs(t *testing.T) {
+	t.Parallel()
+
+	k := NewKeepalivedCollector(Options{JSON: true}, &testCollector{vrrps: []VRRP{{Data: VRRPData{
+		IName: "VI_1",
+		Intf:  "eth0",
+		VRID:  51,
+		State: 2,
+		VIPs:  []VIP{{}},
+	}}}})
+
+	ch := make(chan prometheus.Metric, 100)
+	k.Collect(ch)
+	close(ch)
+
+	var ipAddresses []string
+
+	for m := range ch {
+		if m.Desc() != k.metrics["keepalived_vrrp_state"] {
+			continue
+		}
+
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "ip_address" {
+				ipAddresses = append(ipAddresses, label.GetValue())
+			}
+		}
+	}
+
+	// an instance whose VIPs are all left out still reports its state
+	if !reflect.DeepEqual(ipAddresses, []string{""}) {
+		t.Log(ipAddresses)
+		t.Fail()
+	}
+}
+
+func TestTargetRegisterer(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	// r2 fails to refresh, which must not affect r1
+	targets := map[string]Collector{
+		"r1": &testCollector{vrrps: testVRRPs},
+		"r2": &testCollector{vrrps: testVRRPs, refreshErr: errors.New("keepalived is not running")},
+	}
+	for name, c := range targets {
+		TargetRegisterer(registry, name).MustRegister(NewKeepalivedCollector(Options{JSON: true}, c))
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up := make(map[string]float64)
+
+	for _, family := range families {
+		if family.GetName() != "keepalived_up" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "keepalived" {
+					up[label.GetValue()] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+
+	if len(up) != 2 || up["r1"] != 1 || up["r2"] != 0 {
+		t.Log(up)
+		t.Fail()
+	}
+
+	if TargetRegisterer(registry, "") != registry {
+		t.Fail()
+	}
+}
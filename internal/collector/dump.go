@@ -0,0 +1,88 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// DumpTimeout is how long keepalived is given to rewrite its dump files after being signalled.
+const DumpTimeout = 2 * time.Second
+
+// dumpPollInterval is how often dump files are checked, a file is stable once unchanged between two checks.
+const dumpPollInterval = 10 * time.Millisecond
+
+// ErrStaleDump is returned when keepalived did not rewrite its dump files in time.
+var ErrStaleDump = errors.New("keepalived dump file was not rewritten")
+
+// dumpFileState identifies a version of a dump file.
+type dumpFileState struct {
+	exists  bool
+	ino     uint64
+	size    int64
+	modTime time.Time
+}
+
+func statDumpFile(path string) dumpFileState {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return dumpFileState{}
+	}
+
+	state := dumpFileState{exists: true, size: fi.Size(), modTime: fi.ModTime()}
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		state.ino = st.Ino
+	}
+
+	return state
+}
+
+// WaitForDumps calls signal and waits until keepalived has rewritten every file in paths
+// and their size and modification time are stable.
+// ErrStaleDump is returned when a file is not rewritten or still changing after timeout.
+func WaitForDumps(paths []string, timeout time.Duration, signal func() error) error {
+	before := make([]dumpFileState, len(paths))
+	for i, path := range paths {
+		before[i] = statDumpFile(path)
+	}
+
+	if err := signal(); err != nil {
+		return err
+	}
+
+	last := make([]dumpFileState, len(paths))
+	deadline := time.Now().Add(timeout)
+
+	for {
+		done := true
+
+		for i, path := range paths {
+			state := statDumpFile(path)
+			fresh := state.exists && state != before[i]
+
+			if !fresh || state != last[i] {
+				done = false
+			}
+
+			last[i] = state
+		}
+
+		if done {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			for i, path := range paths {
+				if !last[i].exists || last[i] == before[i] {
+					return fmt.Errorf("%w: %s", ErrStaleDump, path)
+				}
+			}
+
+			return fmt.Errorf("%w: %v still changing", ErrStaleDump, paths)
+		}
+
+		time.Sleep(dumpPollInterval)
+	}
+}
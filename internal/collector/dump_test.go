@@ -0,0 +1,127 @@
+package collector
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForDumps(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	data := filepath.Join(dir, "keepalived.data")
+	stats := filepath.Join(dir, "keepalived.stats")
+
+	if err := os.WriteFile(data, []byte("previous"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// keepalived writes its dumps after the signal is handled
+	err := WaitForDumps([]string{stats, data}, time.Second, func() error {
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+
+			if err := os.WriteFile(stats, []byte("stats"), 0o600); err != nil {
+				t.Error(err)
+			}
+
+			f, err := os.Create(data)
+			if err != nil {
+				t.Error(err)
+
+				return
+			}
+
+			for range 5 {
+				if _, err := f.WriteString("current "); err != nil {
+					t.Error(err)
+				}
+
+				time.Sleep(time.Millisecond)
+			}
+
+			if err := f.Close(); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		return nil
+	})
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	b, err := os.ReadFile(data)
+	if err != nil || strings.Count(string(b), "current") != 5 {
+		t.Log(string(b))
+		t.Fail()
+	}
+}
+
+func TestWaitForDumpsStale(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	data := filepath.Join(dir, "keepalived.data")
+
+	if err := os.WriteFile(data, []byte("previous"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := WaitForDumps([]string{data}, 50*time.Millisecond, func() error { return nil })
+	if !errors.Is(err, ErrStaleDump) {
+		t.Log(err)
+		t.Fail()
+	}
+
+	// missing dump files are never fresh
+	err = WaitForDumps([]string{filepath.Join(dir, "keepalived.stats")}, 50*time.Millisecond, func() error { return nil })
+	if !errors.Is(err, ErrStaleDump) {
+		t.Log(err)
+		t.Fail()
+	}
+}
+
+func TestWaitForDumpsSignalError(t *testing.T) {
+	t.Parallel()
+
+	signalErr := errors.New("no such process")
+
+	err := WaitForDumps([]string{filepath.Join(t.TempDir(), "keepalived.data")}, time.Second, func() error { return signalErr })
+	if !errors.Is(err, signalErr) {
+		t.Fail()
+	}
+}
+
+func TestRefreshWaitsForEnabledDumps(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		options  Options
+		expected []string
+	}{
+		{options: Options{}, expected: nil},
+		{options: Options{Checker: true}, expected: []string{"keepalived_check.data"}},
+		{options: Options{Checker: true, BFD: true}, expected: []string{"keepalived_check.data", "keepalived_bfd.data"}},
+	}
+
+	for _, tc := range testCases {
+		c := &testCollector{refreshErr: ErrStaleDump}
+		k := NewKeepalivedCollector(tc.options, c)
+
+		if _, err := k.getKeepalivedStats(); err == nil {
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual(c.dumps, tc.expected) {
+			t.Log(tc.options, c.dumps)
+			t.Fail()
+		}
+	}
+}
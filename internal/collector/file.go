@@ -0,0 +1,33 @@
+package collector
+
+import (
+	"io"
+	"log/slog"
+	"os"
+)
+
+// ParseFile opens the dump file of path, parses it with parse and closes it.
+func ParseFile[T any](path string, parse func(io.Reader) (T, error)) (T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		slog.Error("Failed to open Keepalived dump file",
+			"fileName", path,
+			"error", err,
+		)
+
+		var zero T
+
+		return zero, err
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			slog.Error("Failed to close Keepalived dump file",
+				"fileName", path,
+				"error", err,
+			)
+		}
+	}()
+
+	return parse(f)
+}
@@ -0,0 +1,20 @@
+package collector
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+
+	stats, err := ParseFile("../../test_files/v2.1.5/keepalived.stats", ParseStats)
+	if err != nil || len(stats) == 0 {
+		t.Log(err)
+		t.Fail()
+	}
+
+	if _, err := ParseFile(filepath.Join(t.TempDir(), "keepalived.stats"), ParseStats); err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,416 @@
+package collector
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/moby/ipvs"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/unix"
+)
+
+var ipvsProtocols = map[string]uint16{"TCP": unix.IPPROTO_TCP, "UDP": unix.IPPROTO_UDP, "SCTP": unix.IPPROTO_SCTP}
+
+// IPVSService represents IPVS virtual server read from the kernel.
+type IPVSService struct {
+	Name         string
+	Stats        *IPVSStats
+	Destinations []IPVSDestination
+}
+
+// IPVSDestination represents IPVS real server of a virtual server.
+type IPVSDestination struct {
+	Name          string
+	Weight        int
+	ActiveConns   int
+	InactiveConns int
+	Stats         *IPVSStats
+}
+
+// IPVSStats represents IPVS traffic counters. It's nil when read from /proc/net/ip_vs.
+type IPVSStats struct {
+	Connections uint64
+	PacketsIn   uint64
+	PacketsOut  uint64
+	BytesIn     uint64
+	BytesOut    uint64
+}
+
+// IPVSCollector implements prometheus.Collector interface and exports IPVS statistics.
+// It reads the kernel tables on its own, so they're exported even when keepalived is down.
+type IPVSCollector struct {
+	collector  Collector
+	keepalived *KeepalivedCollector
+	metrics    map[string]*prometheus.Desc
+}
+
+// NewIPVSCollector is creating new instance of IPVSCollector.
+// With checker enabled in keepalived, only virtual servers of its latest refresh are exported.
+func NewIPVSCollector(collector Collector, keepalived *KeepalivedCollector) *IPVSCollector {
+	ic := &IPVSCollector{collector: collector, keepalived: keepalived}
+	ic.fillMetrics()
+
+	return ic
+}
+
+func (ic *IPVSCollector) fillMetrics() {
+	vsMetrics := map[string]string{
+		"keepalived_ipvs_virtual_server_connections_total": "Total connections of IPVS virtual server",
+		"keepalived_ipvs_virtual_server_packets_in_total":  "Total incoming packets of IPVS virtual server",
+		"keepalived_ipvs_virtual_server_packets_out_total": "Total outgoing packets of IPVS virtual server",
+		"keepalived_ipvs_virtual_server_bytes_in_total":    "Total incoming bytes of IPVS virtual server",
+		"keepalived_ipvs_virtual_server_bytes_out_total":   "Total outgoing bytes of IPVS virtual server",
+	}
+
+	rsMetrics := map[string]string{
+		"keepalived_ipvs_real_server_connections_total":    "Total connections of IPVS real server",
+		"keepalived_ipvs_real_server_packets_in_total":     "Total incoming packets of IPVS real server",
+		"keepalived_ipvs_real_server_packets_out_total":    "Total outgoing packets of IPVS real server",
+		"keepalived_ipvs_real_server_bytes_in_total":       "Total incoming bytes of IPVS real server",
+		"keepalived_ipvs_real_server_bytes_out_total":      "Total outgoing bytes of IPVS real server",
+		"keepalived_ipvs_real_server_active_connections":   "Active connections of IPVS real server",
+		"keepalived_ipvs_real_server_inactive_connections": "Inactive connections of IPVS real server",
+		"keepalived_ipvs_real_server_weight":               "Weight of IPVS real server",
+	}
+
+	ic.metrics = map[string]*prometheus.Desc{
+		"keepalived_ipvs_up": prometheus.NewDesc("keepalived_ipvs_up", "Status of IPVS tables read", nil, nil),
+	}
+
+	for metric, help := range vsMetrics {
+		ic.metrics[metric] = prometheus.NewDesc(metric, help, []string{"vs"}, nil)
+	}
+
+	for metric, help := range rsMetrics {
+		ic.metrics[metric] = prometheus.NewDesc(metric, help, []string{"vs", "rs"}, nil)
+	}
+}
+
+// Describe outputs IPVS metrics descriptions.
+func (ic *IPVSCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range ic.metrics {
+		ch <- m
+	}
+}
+
+// Collect reads the IPVS tables and outputs their metrics.
+func (ic *IPVSCollector) Collect(ch chan<- prometheus.Metric) {
+	services, err := ic.collector.IPVSServices()
+	if err != nil {
+		slog.Error("Failed to read IPVS tables", "error", err)
+		ic.newConstMetric(ch, "keepalived_ipvs_up", prometheus.GaugeValue, 0)
+
+		return
+	}
+
+	ic.newConstMetric(ch, "keepalived_ipvs_up", prometheus.GaugeValue, 1)
+
+	managed := ic.keepalived.managedVirtualServers()
+
+	for _, svc := range services {
+		if managed != nil && !managed[svc.Name] {
+			continue
+		}
+
+		ic.collectStats(ch, "keepalived_ipvs_virtual_server", svc.Stats, svc.Name)
+
+		for _, dst := range svc.Destinations {
+			ic.collectStats(ch, "keepalived_ipvs_real_server", dst.Stats, svc.Name, dst.Name)
+			ic.newConstMetric(ch, "keepalived_ipvs_real_server_active_connections", prometheus.GaugeValue, float64(dst.ActiveConns), svc.Name, dst.Name)
+			ic.newConstMetric(ch, "keepalived_ipvs_real_server_inactive_connections", prometheus.GaugeValue, float64(dst.InactiveConns), svc.Name, dst.Name)
+			ic.newConstMetric(ch, "keepalived_ipvs_real_server_weight", prometheus.GaugeValue, float64(dst.Weight), svc.Name, dst.Name)
+		}
+	}
+}
+
+func (ic *IPVSCollector) collectStats(ch chan<- prometheus.Metric, prefix string, stats *IPVSStats, labelValues ...string) {
+	if stats == nil {
+		return
+	}
+
+	ic.newConstMetric(ch, prefix+"_connections_total", prometheus.CounterValue, float64(stats.Connections), labelValues...)
+	ic.newConstMetric(ch, prefix+"_packets_in_total", prometheus.CounterValue, float64(stats.PacketsIn), labelValues...)
+	ic.newConstMetric(ch, prefix+"_packets_out_total", prometheus.CounterValue, float64(stats.PacketsOut), labelValues...)
+	ic.newConstMetric(ch, prefix+"_bytes_in_total", prometheus.CounterValue, float64(stats.BytesIn), labelValues...)
+	ic.newConstMetric(ch, prefix+"_bytes_out_total", prometheus.CounterValue, float64(stats.BytesOut), labelValues...)
+}
+
+func (ic *IPVSCollector) newConstMetric(
+	ch chan<- prometheus.Metric,
+	name string,
+	valueType prometheus.ValueType,
+	value float64,
+	labelValues ...string,
+) {
+	pm, err := prometheus.NewConstMetric(
+		ic.metrics[name],
+		valueType,
+		value,
+		labelValues...,
+	)
+	if err != nil {
+		slog.Error("Failed to send metric",
+			"name", name,
+			"labels", strings.Join(labelValues, ","),
+			"error", err,
+		)
+
+		return
+	}
+
+	ch <- pm
+}
+
+// managedVirtualServers returns names of the checker virtual servers of the latest snapshot.
+// It's nil when checker is disabled or keepalived data wasn't read, then all IPVS virtual servers are exported.
+func (k *KeepalivedCollector) managedVirtualServers() map[string]bool {
+	if !k.options.Checker {
+		return nil
+	}
+
+	k.Lock()
+	s := k.snapshot
+	k.Unlock()
+
+	if s == nil || s.stats == nil {
+		return nil
+	}
+
+	managed := make(map[string]bool, len(s.stats.VirtualServers))
+	for _, vs := range s.stats.VirtualServers {
+		managed[vs.Name] = true
+	}
+
+	return managed
+}
+
+// ReadIPVS reads IPVS tables over generic netlink in the namespace of netnsPath and
+// falls back to procPath (/proc/net/ip_vs) when netlink isn't available.
+// An empty netnsPath means the current network namespace.
+func ReadIPVS(netnsPath, procPath string) ([]IPVSService, error) {
+	services, err := readIPVSNetlink(netnsPath)
+	if err == nil {
+		return services, nil
+	}
+
+	slog.Debug("Failed to read IPVS over netlink, falling back to procfs",
+		"error", err,
+		"path", procPath,
+	)
+
+	f, err := os.Open(procPath)
+	if err != nil {
+		slog.Error("Failed to open IPVS proc file",
+			"path", procPath,
+			"error", err,
+		)
+
+		return nil, err
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			slog.Error("Failed to close IPVS proc file",
+				"path", procPath,
+				"error", err,
+			)
+		}
+	}()
+
+	return ParseProcIPVS(f)
+}
+
+func readIPVSNetlink(netnsPath string) ([]IPVSService, error) {
+	h, err := ipvs.New(netnsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Close()
+
+	svcs, err := h.GetServices()
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]IPVSService, 0, len(svcs))
+
+	for _, svc := range svcs {
+		dsts, err := h.GetDestinations(svc)
+		if err != nil {
+			return nil, err
+		}
+
+		service := IPVSService{
+			Name:  ipvsServiceName(svc.FWMark, svc.Address, svc.Protocol, svc.Port),
+			Stats: newIPVSStats(svc.Stats),
+		}
+
+		for _, dst := range dsts {
+			service.Destinations = append(service.Destinations, IPVSDestination{
+				Name:          ipvsDestinationName(svc.FWMark, dst.Address, svc.Protocol, dst.Port),
+				Weight:        dst.Weight,
+				ActiveConns:   dst.ActiveConnections,
+				InactiveConns: dst.InactiveConnections,
+				Stats:         newIPVSStats(ipvs.SvcStats(dst.Stats)),
+			})
+		}
+
+		services = append(services, service)
+	}
+
+	return services, nil
+}
+
+func newIPVSStats(stats ipvs.SvcStats) *IPVSStats {
+	return &IPVSStats{
+		Connections: uint64(stats.Connections),
+		PacketsIn:   uint64(stats.PacketsIn),
+		PacketsOut:  uint64(stats.PacketsOut),
+		BytesIn:     stats.BytesIn,
+		BytesOut:    stats.BytesOut,
+	}
+}
+
+// ipvsServiceName formats virtual server the same way Keepalived does in keepalived_check.data.
+func ipvsServiceName(fwmark uint32, addr net.IP, protocol, port uint16) string {
+	if fwmark != 0 {
+		return fmt.Sprintf("FWM %d", fwmark)
+	}
+
+	return fmt.Sprintf("[%s]:%s:%d", addr, ipvsProtocolName(protocol), port)
+}
+
+// ipvsDestinationName formats real server the same way Keepalived does in keepalived_check.data.
+func ipvsDestinationName(fwmark uint32, addr net.IP, protocol, port uint16) string {
+	if fwmark != 0 {
+		return fmt.Sprintf("[%s]:%d", addr, port)
+	}
+
+	return fmt.Sprintf("[%s]:%s:%d", addr, ipvsProtocolName(protocol), port)
+}
+
+func ipvsProtocolName(protocol uint16) string {
+	switch protocol {
+	case unix.IPPROTO_TCP:
+		return "tcp"
+	case unix.IPPROTO_UDP:
+		return "udp"
+	case unix.IPPROTO_SCTP:
+		return "sctp"
+	default:
+		return strconv.Itoa(int(protocol))
+	}
+}
+
+// ParseProcIPVS parses IPVS virtual and real servers from /proc/net/ip_vs.
+// Traffic counters aren't available there, so only weights and connections are set.
+func ParseProcIPVS(i io.Reader) ([]IPVSService, error) {
+	services := make([]IPVSService, 0)
+
+	var (
+		svc      *IPVSService
+		fwmark   uint32
+		protocol uint16
+	)
+
+	scanner := bufio.NewScanner(bufio.NewReader(i))
+
+	for scanner.Scan() {
+		args := strings.Fields(scanner.Text())
+		if len(args) < 2 {
+			continue
+		}
+
+		switch args[0] {
+		case "TCP", "UDP", "SCTP":
+			addr, port, err := parseProcIPVSAddr(args[1])
+			if err != nil {
+				return services, err
+			}
+
+			protocol = ipvsProtocols[args[0]]
+			fwmark = 0
+
+			services = append(services, IPVSService{Name: ipvsServiceName(fwmark, addr, protocol, port)})
+			svc = &services[len(services)-1]
+		case "FWM":
+			mark, err := strconv.ParseUint(args[1], 16, 32)
+			if err != nil {
+				return services, err
+			}
+
+			fwmark = uint32(mark)
+			protocol = 0
+
+			services = append(services, IPVSService{Name: ipvsServiceName(fwmark, nil, protocol, 0)})
+			svc = &services[len(services)-1]
+		case "->":
+			// "-> RemoteAddress:Port Forward Weight ActiveConn InActConn"
+			if svc == nil || len(args) < 6 || args[1] == "RemoteAddress:Port" {
+				continue
+			}
+
+			addr, port, err := parseProcIPVSAddr(args[1])
+			if err != nil {
+				return services, err
+			}
+
+			var dst IPVSDestination
+
+			dst.Name = ipvsDestinationName(fwmark, addr, protocol, port)
+
+			if dst.Weight, err = strconv.Atoi(args[3]); err != nil {
+				return services, err
+			}
+
+			if dst.ActiveConns, err = strconv.Atoi(args[4]); err != nil {
+				return services, err
+			}
+
+			if dst.InactiveConns, err = strconv.Atoi(args[5]); err != nil {
+				return services, err
+			}
+
+			svc.Destinations = append(svc.Destinations, dst)
+		}
+	}
+
+	return services, nil
+}
+
+// parseProcIPVSAddr parses address in "0A000064:0050" or "[fd00:0000:...:0100]:01BB" format.
+func parseProcIPVSAddr(s string) (net.IP, uint16, error) {
+	idx := strings.LastIndex(s, ":")
+	if idx < 0 {
+		return nil, 0, fmt.Errorf("invalid IPVS address: %s", s)
+	}
+
+	port, err := strconv.ParseUint(s[idx+1:], 16, 16)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	host := s[:idx]
+	if strings.HasPrefix(host, "[") {
+		addr := net.ParseIP(strings.Trim(host, "[]"))
+		if addr == nil {
+			return nil, 0, fmt.Errorf("invalid IPVS address: %s", s)
+		}
+
+		return addr, uint16(port), nil
+	}
+
+	b, err := hex.DecodeString(host)
+	if err != nil || len(b) != net.IPv4len {
+		return nil, 0, fmt.Errorf("invalid IPVS address: %s", s)
+	}
+
+	return net.IP(b), uint16(port), nil
+}
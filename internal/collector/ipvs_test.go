@@ -0,0 +1,172 @@
+package collector
+
+import (
+	"errors"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestParseProcIPVS(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/ip_vs")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	services, err := ParseProcIPVS(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := []IPVSService{
+		{
+			Name: "[10.0.0.100]:tcp:80",
+			Destinations: []IPVSDestination{
+				{Name: "[192.168.1.11]:tcp:80", Weight: 1, ActiveConns: 12, InactiveConns: 3},
+				{Name: "[192.168.1.12]:tcp:80", Weight: 1, ActiveConns: 10, InactiveConns: 4},
+				{Name: "[192.168.1.13]:tcp:80", Weight: 0, ActiveConns: 0, InactiveConns: 0},
+			},
+		},
+		{
+			Name:         "[fd00::100]:tcp:443",
+			Destinations: []IPVSDestination{{Name: "[fd00::21]:tcp:443", Weight: 3}},
+		},
+		{
+			Name:         "[10.0.0.200]:udp:53",
+			Destinations: []IPVSDestination{{Name: "[192.168.1.21]:udp:53", Weight: 1, InactiveConns: 7}},
+		},
+		{
+			Name:         "FWM 1",
+			Destinations: []IPVSDestination{{Name: "[192.168.1.22]:0", Weight: 1, ActiveConns: 2}},
+		},
+	}
+	if !reflect.DeepEqual(services, expected) {
+		t.Fail()
+	}
+}
+
+func TestParseProcIPVSAddr(t *testing.T) {
+	t.Parallel()
+
+	addr, port, err := parseProcIPVSAddr("0A000064:0050")
+	if err != nil || !addr.Equal(net.ParseIP("10.0.0.100")) || port != 80 {
+		t.Fail()
+	}
+
+	addr, port, err = parseProcIPVSAddr("[fd00:0000:0000:0000:0000:0000:0000:0100]:01BB")
+	if err != nil || !addr.Equal(net.ParseIP("fd00::100")) || port != 443 {
+		t.Fail()
+	}
+
+	for _, invalid := range []string{"0A000064", "0A00:0050", "[fd00::zz]:0050", "0A000064:zz"} {
+		if _, _, err := parseProcIPVSAddr(invalid); err == nil {
+			t.Log(invalid)
+			t.Fail()
+		}
+	}
+}
+
+func TestIPVSCollector(t *testing.T) {
+	t.Parallel()
+
+	stats := &IPVSStats{Connections: 10, PacketsIn: 20, PacketsOut: 30, BytesIn: 40, BytesOut: 50}
+	c := &testCollector{
+		services: []IPVSService{
+			{
+				Name:         "[10.0.0.100]:tcp:80",
+				Stats:        stats,
+				Destinations: []IPVSDestination{{Name: "[192.168.1.11]:tcp:80", Weight: 1, ActiveConns: 5, Stats: stats}},
+			},
+			{Name: "[10.0.0.200]:tcp:80", Stats: stats},
+		},
+	}
+
+	collect := func(k *KeepalivedCollector) (map[string]int, float64) {
+		ic := NewIPVSCollector(c, k)
+
+		ch := make(chan prometheus.Metric, 100)
+		ic.Collect(ch)
+		close(ch)
+
+		series := make(map[string]int)
+		up := float64(-1)
+
+		for m := range ch {
+			metric := &dto.Metric{}
+			if err := m.Write(metric); err != nil {
+				t.Fatal(err)
+			}
+
+			if m.Desc() == ic.metrics["keepalived_ipvs_up"] {
+				up = metric.GetGauge().GetValue()
+			}
+
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "vs" {
+					series[label.GetValue()]++
+				}
+			}
+		}
+
+		return series, up
+	}
+
+	all := map[string]int{"[10.0.0.100]:tcp:80": 13, "[10.0.0.200]:tcp:80": 5}
+
+	k := NewKeepalivedCollector(Options{Checker: true}, c)
+
+	// virtual servers are exported unfiltered until checker data is read
+	series, up := collect(k)
+	if up != 1 || !reflect.DeepEqual(series, all) {
+		t.Fail()
+	}
+
+	// virtual server stats, real server stats, connections and weight of checker virtual servers only
+	k.snapshot = &snapshot{stats: &KeepalivedStats{VirtualServers: []VirtualServer{{Name: "[10.0.0.100]:tcp:80"}}}}
+
+	series, up = collect(k)
+	if up != 1 || !reflect.DeepEqual(series, map[string]int{"[10.0.0.100]:tcp:80": 13}) {
+		t.Fail()
+	}
+
+	series, up = collect(NewKeepalivedCollector(Options{}, c))
+	if up != 1 || !reflect.DeepEqual(series, all) {
+		t.Fail()
+	}
+}
+
+func TestIPVSCollectorError(t *testing.T) {
+	t.Parallel()
+
+	c := &testCollector{vrrps: testVRRPs, ipvsErr: errors.New("no IPVS support")}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+	ic := NewIPVSCollector(c, k)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(k, ic)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gauges := make(map[string]float64)
+	for _, family := range families {
+		gauges[family.GetName()] = family.GetMetric()[0].GetGauge().GetValue()
+	}
+
+	// a failing IPVS read never affects keepalived data
+	if gauges["keepalived_ipvs_up"] != 0 || gauges["keepalived_up"] != 1 {
+		t.Log(gauges)
+		t.Fail()
+	}
+}
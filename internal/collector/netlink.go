@@ -0,0 +1,124 @@
+package collector
+
+import (
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// newNetlinkHandle returns a netlink handle in the namespace of netnsPath.
+// An empty netnsPath means the current network namespace.
+func newNetlinkHandle(netnsPath string) (*netlink.Handle, error) {
+	if netnsPath == "" {
+		return netlink.NewHandle()
+	}
+
+	ns, err := netns.GetFromPath(netnsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer ns.Close() //nolint: errcheck
+
+	return netlink.NewHandleAt(ns)
+}
+
+// ReadInterfaceAddresses returns the kernel addresses of each interface in the namespace of netnsPath.
+func ReadInterfaceAddresses(netnsPath string) (map[string][]string, error) {
+	h, err := newNetlinkHandle(netnsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Delete()
+
+	links, err := h.LinkList()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make(map[string][]string, len(links))
+
+	for _, link := range links {
+		addrs, err := h.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, err
+		}
+
+		name := link.Attrs().Name
+		for _, addr := range addrs {
+			addresses[name] = append(addresses[name], addr.IP.String())
+		}
+	}
+
+	return addresses, nil
+}
+
+// ReadRoutingTables returns the routes of all tables and the rules in the namespace of netnsPath.
+func ReadRoutingTables(netnsPath string) (*RoutingTables, error) {
+	h, err := newNetlinkHandle(netnsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer h.Delete()
+
+	rules, err := h.RuleList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := &RoutingTables{
+		Routes: make([]VirtualRoute, 0),
+		Rules:  make([]VirtualRule, 0, len(rules)),
+	}
+
+	// routes are listed per family, as default routes of both families have no destination
+	families := []struct {
+		id   int
+		name string
+	}{{netlink.FAMILY_V4, "ipv4"}, {netlink.FAMILY_V6, "ipv6"}}
+
+	for _, family := range families {
+		routes, err := h.RouteListFiltered(family.id, &netlink.Route{}, netlink.RT_FILTER_TABLE)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, route := range routes {
+			vr := VirtualRoute{
+				Dst:    ipNetString(route.Dst, "default"),
+				Family: family.name,
+				Via:    ipString(route.Gw),
+				Metric: route.Priority,
+				Table:  route.Table,
+			}
+
+			if len(route.MultiPath) == 0 {
+				tables.Routes = append(tables.Routes, vr)
+
+				continue
+			}
+
+			// every nexthop of an ECMP route is matched on its own
+			for _, nh := range route.MultiPath {
+				nexthop := vr
+				nexthop.Via = ipString(nh.Gw)
+				tables.Routes = append(tables.Routes, nexthop)
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		tables.Rules = append(tables.Rules, VirtualRule{From: ipNetString(rule.Src, "all"), To: ipNetString(rule.Dst, "all"), Table: rule.Table})
+	}
+
+	return tables, nil
+}
+
+// ipString returns the address or empty string when it is nil.
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+
+	return ip.String()
+}
@@ -0,0 +1,19 @@
+package collector
+
+// Options selects the data sources and metrics of KeepalivedCollector.
+type Options struct {
+	// JSON decodes keepalived.json instead of parsing text files.
+	JSON bool
+	// Checker collects LVS virtual servers from keepalived_check.data.
+	Checker bool
+	// BFD collects BFD instances from keepalived_bfd.data.
+	BFD bool
+	// VerifyVIPs compares VIPs with the kernel addresses of their interfaces.
+	VerifyVIPs bool
+	// VerifyRoutes looks up virtual routes and rules in the kernel routing tables.
+	VerifyRoutes bool
+	// InstanceStateSet exports keepalived_vrrp_instance_state as one series per VRRP state.
+	InstanceStateSet bool
+	// ScriptPath is executed for each VIP when set.
+	ScriptPath string
+}
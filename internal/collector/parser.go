@@ -3,8 +3,11 @@ package collector
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"strconv"
 	"strings"
 
@@ -18,6 +21,17 @@ var (
 	VRRPScriptStates = []string{"idle", "running", "requested termination", "forcing termination"}
 	// VRRPStates contains VRRP states.
 	VRRPStates = []string{"INIT", "BACKUP", "MASTER", "FAULT"}
+	// BFDStates contains BFD session states in RFC 5880 order.
+	BFDStates = []string{"AdminDown", "Down", "Init", "Up"}
+
+	durationUnits = map[string]float64{
+		"sec":       1,
+		"secs":      1,
+		"milli-sec": 1e3,
+		"msec":      1e3,
+		"usec":      1e6,
+		"usecs":     1e6,
+	}
 )
 
 func (v *VRRPScript) getIntStatus() (int, bool) {
@@ -50,6 +64,74 @@ func vrrpDataStringToIntState(state string) (int, bool) {
 	return -1, false
 }
 
+func bfdStringToIntState(state string) (int, bool) {
+	for i, s := range BFDStates {
+		if s == state {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// parseSeconds converts unit-suffixed durations of keepalived.data like "4000 milli-sec" to seconds.
+func parseSeconds(value string) (float64, error) {
+	args := strings.Fields(value)
+	if len(args) != 2 {
+		return 0, fmt.Errorf("unknown duration format: %s", value)
+	}
+
+	divisor, ok := durationUnits[args[1]]
+	if !ok {
+		return 0, fmt.Errorf("unknown duration unit: %s", args[1])
+	}
+
+	duration, err := strconv.ParseFloat(args[0], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return duration / divisor, nil
+}
+
+// UnmarshalJSON decodes a tracked script from either its name or an object with name and weight.
+func (t *VRRPTrackedScript) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		t.Name = name
+
+		return nil
+	}
+
+	type trackedScript VRRPTrackedScript
+
+	return json.Unmarshal(b, (*trackedScript)(t))
+}
+
+// UnmarshalJSON decodes a tracked interface from either its name or an object with name, weight and status.
+func (t *VRRPTrackedInterface) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		t.Name = name
+
+		return nil
+	}
+
+	type trackedInterface VRRPTrackedInterface
+
+	return json.Unmarshal(b, (*trackedInterface)(t))
+}
+
+// parseTimestamp converts timestamps of keepalived.data like "1673674892.348360 (Sat Jan 14 06:41:32.348360 2023)" to seconds.
+func parseTimestamp(value string) (float64, error) {
+	args := strings.Fields(value)
+	if len(args) == 0 {
+		return 0, errors.New("empty timestamp found")
+	}
+
+	return strconv.ParseFloat(args[0], 64)
+}
+
 func ParseJSON(i io.Reader) ([]VRRP, error) {
 	stats := make([]VRRP, 0)
 
@@ -63,7 +145,7 @@ func ParseJSON(i io.Reader) ([]VRRP, error) {
 
 // isKeyArray checks if key is array in keepalived.data file.
 func isKeyArray(key string) bool {
-	supportedKeys := []string{"Virtual IP"}
+	supportedKeys := []string{"Virtual IP", "Tracked scripts", "Tracked interfaces", "Tracked BFDs", "Unicast Peer"}
 	if slices.Contains(supportedKeys, key) {
 		return true
 	}
@@ -83,7 +165,10 @@ func ParseVRRPData(i io.Reader) (map[string]*VRRPData, error) {
 	prop := "="
 	arrayProp := ":"
 
-	var instance, key, val string
+	var instance, intf, key, val string
+
+	intfStatuses := make(map[string]string)
+	intfMACs := make(map[string]string)
 
 	scanner := bufio.NewScanner(bufio.NewReader(i))
 
@@ -94,7 +179,24 @@ func ParseVRRPData(i io.Reader) (map[string]*VRRPData, error) {
 		case strings.HasPrefix(l, " "+sep) && strings.Contains(l, prop):
 			s := strings.Split(strings.TrimSpace(l), prop)
 			instance = strings.TrimSpace(s[1])
+			intf = ""
 			data[instance] = &VRRPData{IName: instance}
+		case strings.HasPrefix(l, " Name = "):
+			// Interfaces section
+			instance = ""
+			intf = strings.TrimSpace(strings.TrimPrefix(l, " Name = "))
+		case strings.HasPrefix(l, "   ") && intf != "":
+			s := strings.Split(strings.TrimSpace(l), prop)
+			if len(s) != 2 {
+				continue
+			}
+
+			switch strings.TrimSpace(s[0]) {
+			case "State":
+				intfStatuses[intf] = parseIntfStatus(s[1])
+			case "MAC":
+				intfMACs[intf] = strings.TrimSpace(s[1])
+			}
 		case strings.HasPrefix(l, "   ") && instance != "":
 			if strings.HasPrefix(l, "     ") {
 				val = strings.TrimSpace(l)
@@ -102,6 +204,10 @@ func ParseVRRPData(i io.Reader) (map[string]*VRRPData, error) {
 				var args []string
 
 				switch {
+				case strings.HasPrefix(strings.TrimSpace(l), "Multicast address"):
+					// printed without separator like "Multicast address 224.0.0.18"
+					mcast := strings.TrimPrefix(strings.TrimSpace(l), "Multicast address")
+					args = []string{"Multicast address", strings.TrimLeft(mcast, " =")}
 				case strings.Contains(l, prop):
 					args = strings.Split(strings.TrimSpace(l), prop)
 				case strings.Contains(l, arrayProp):
@@ -126,6 +232,36 @@ func ParseVRRPData(i io.Reader) (map[string]*VRRPData, error) {
 				data[instance].addExcludedVIP(val)
 			}
 
+			if key == "Tracked interfaces" && val != "" {
+				if err := data[instance].addTrackedInterface(val); err != nil {
+					return data, err
+				}
+			}
+
+			if strings.HasPrefix(key, "Virtual Routes") && val != "" {
+				data[instance].addVirtualRoute(val)
+			}
+
+			if strings.HasPrefix(key, "Virtual Rules") && val != "" {
+				data[instance].addVirtualRule(val)
+			}
+
+			if key == "Unicast Peer" && val != "" {
+				data[instance].addUnicastPeer(val)
+			}
+
+			if key == "Tracked scripts" && val != "" {
+				if err := data[instance].addTrackedScript(val); err != nil {
+					return data, err
+				}
+			}
+
+			if key == "Tracked BFDs" && val != "" {
+				if err := data[instance].addTrackedBFD(val); err != nil {
+					return data, err
+				}
+			}
+
 			switch key {
 			case "State":
 				if err := data[instance].setState(val); err != nil {
@@ -136,7 +272,11 @@ func ParseVRRPData(i io.Reader) (map[string]*VRRPData, error) {
 					return data, err
 				}
 			case "Interface", "Listening device":
-				data[instance].Intf = val
+				data[instance].setIntf(val)
+			case "Using src_ip":
+				data[instance].setSrcIP(val)
+			case "Multicast address":
+				data[instance].McastGroup = val
 			case "Gratuitous ARP delay":
 				if err := data[instance].setGArpDelay(val); err != nil {
 					return data, err
@@ -145,23 +285,77 @@ func ParseVRRPData(i io.Reader) (map[string]*VRRPData, error) {
 				if err := data[instance].setVRID(val); err != nil {
 					return data, err
 				}
+			case "Priority":
+				if err := data[instance].setPriority(val); err != nil {
+					return data, err
+				}
+			case "Effective priority":
+				if err := data[instance].setEffectivePriority(val); err != nil {
+					return data, err
+				}
+			case "Total priority":
+				if err := data[instance].setTotalPriority(val); err != nil {
+					return data, err
+				}
+			case "Number of config faults":
+				if err := data[instance].setConfigFaults(val); err != nil {
+					return data, err
+				}
+			case "Number of interface and track script faults":
+				if err := data[instance].setTrackFaults(val); err != nil {
+					return data, err
+				}
+			case "Number of track scripts init":
+				if err := data[instance].setTrackScriptsInit(val); err != nil {
+					return data, err
+				}
+			case "Advert interval":
+				data[instance].setAdvertInterval(val)
+			case "Master down timer":
+				data[instance].setMasterDownTimer(val)
+			case "Down timer adverts":
+				data[instance].setDownTimerAdverts(val)
+			case "Last transition":
+				if err := data[instance].setLastTransition(val); err != nil {
+					return data, err
+				}
+			case "Read timeout":
+				data[instance].setReadTimeout(val)
 			}
 		case strings.HasPrefix(l, " VRRP Version") || strings.HasPrefix(l, " VRRP Script"):
 			// Seen in version <= 1.3.5
 			continue
 		default:
 			instance = ""
+			intf = ""
 		}
 	}
 
+	for _, vrrpData := range data {
+		vrrpData.setTrackedInterfacesStatus(intfStatuses)
+		vrrpData.setMAC(intfMACs)
+	}
+
 	return data, nil
 }
 
+// parseIntfStatus returns UP or DOWN from interface state like "UP, RUNNING".
+func parseIntfStatus(state string) string {
+	if strings.HasPrefix(strings.TrimSpace(state), "UP") {
+		return "UP"
+	}
+
+	return "DOWN"
+}
+
 func ParseVRRPScript(i io.Reader) []VRRPScript {
 	scripts := make([]VRRPScript, 0)
 
 	sep := "VRRP Script"
 	prop := "="
+	arrayProp := ":"
+
+	var key string
 
 	script := VRRPScript{}
 	scanner := bufio.NewScanner(bufio.NewReader(i))
@@ -178,16 +372,42 @@ func ParseVRRPScript(i io.Reader) []VRRPScript {
 
 			s := strings.Split(strings.TrimSpace(l), prop)
 			script.Name = strings.TrimSpace(s[1])
+			key = ""
+		case strings.HasPrefix(l, "     ") && script.Name != "":
+			// "VRRP instances" lists tracking instances in version <= 2.0
+			if key == "Tracking instances" || key == "VRRP instances" {
+				script.addInstance(strings.TrimSpace(l))
+			}
 		case strings.HasPrefix(l, "   ") && script.Name != "":
 			if !strings.Contains(l, prop) {
+				if strings.HasSuffix(l, arrayProp) {
+					key = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(l), arrayProp))
+				}
+
 				continue
 			}
 
-			s := strings.Split(strings.TrimSpace(l), prop)
-			key := strings.TrimSpace(s[0])
+			s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+			key = strings.TrimSpace(s[0])
 			val := strings.TrimSpace(s[1])
 
 			switch key {
+			case "Command":
+				script.Command = val
+			case "Interval":
+				script.Interval = script.parseSeconds(key, val)
+			case "Timeout":
+				script.Timeout = script.parseSeconds(key, val)
+			case "Weight":
+				script.Weight = script.parseInt(key, val)
+			case "Rise":
+				script.Rise = script.parseInt(key, val)
+			case "Fall":
+				script.Fall = script.parseInt(key, val)
+			case "Result":
+				script.Result = script.parseInt(key, val)
+			case "Init state":
+				script.InitState = val
 			case "Status":
 				script.Status = val
 			case "State":
@@ -208,6 +428,391 @@ func ParseVRRPScript(i io.Reader) []VRRPScript {
 	return scripts
 }
 
+func ParseGlobalDefinitions(i io.Reader) (*GlobalDefinitions, error) {
+	global := &GlobalDefinitions{}
+
+	sep := "< Global definitions >"
+	prop := "="
+
+	var inSection bool
+
+	scanner := bufio.NewScanner(bufio.NewReader(i))
+
+	for scanner.Scan() {
+		l := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(l, "------<"):
+			inSection = strings.Contains(l, sep)
+		case inSection && strings.HasPrefix(l, " Script security "):
+			global.ScriptSecurity = strings.TrimSpace(strings.TrimPrefix(l, " Script security ")) == "enabled"
+		case inSection && strings.HasPrefix(l, " ") && strings.Contains(l, prop):
+			s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+			key := strings.TrimSpace(s[0])
+			val := strings.TrimSpace(s[1])
+
+			switch key {
+			case "Router ID":
+				global.RouterID = val
+			case "Instance name":
+				global.InstanceName = val
+			case "Network namespace":
+				global.Namespace = val
+			case "Dynamic interfaces":
+				global.DynamicInterfaces = val == "true"
+			case "Startup time":
+				if err := global.setStartupTime(val); err != nil {
+					return global, err
+				}
+			case "Config load time":
+				if err := global.setConfigLoadTime(val); err != nil {
+					return global, err
+				}
+			}
+		}
+	}
+
+	return global, nil
+}
+
+func ParseVRRPSyncGroups(i io.Reader) ([]VRRPSyncGroup, error) {
+	groups := make([]VRRPSyncGroup, 0)
+
+	sep := "VRRP Sync Group"
+	prop := "="
+
+	var (
+		group *VRRPSyncGroup
+		key   string
+	)
+
+	scanner := bufio.NewScanner(bufio.NewReader(i))
+
+	for scanner.Scan() {
+		l := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(l, " "+sep) && strings.Contains(l, prop):
+			if group != nil {
+				groups = append(groups, *group)
+			}
+
+			// value is in "VG_1, MASTER" format
+			s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+			args := strings.Split(s[1], ",")
+			group = &VRRPSyncGroup{Name: strings.TrimSpace(args[0]), NotifyScripts: make(map[string]string)}
+			key = ""
+
+			if len(args) == 2 {
+				if err := group.setState(strings.TrimSpace(args[1])); err != nil {
+					return groups, err
+				}
+			}
+		case strings.HasPrefix(l, "     ") && group != nil:
+			if key == "VRRP member instances" {
+				group.Instances = append(group.Instances, strings.TrimSpace(l))
+			}
+		case strings.HasPrefix(l, "   ") && group != nil:
+			if !strings.Contains(l, prop) {
+				continue
+			}
+
+			s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+			key = strings.TrimSpace(s[0])
+			val := strings.TrimSpace(s[1])
+
+			switch {
+			case key == "State":
+				if err := group.setState(val); err != nil {
+					return groups, err
+				}
+			case strings.HasSuffix(key, "state transition script"):
+				group.addNotifyScript(key, val)
+			}
+		default:
+			if group != nil {
+				groups = append(groups, *group)
+				group = nil
+			}
+		}
+	}
+
+	if group != nil {
+		groups = append(groups, *group)
+	}
+
+	return groups, nil
+}
+
+// ParseCheckerData parses LVS virtual servers and their health checkers from keepalived_check.data.
+func ParseCheckerData(i io.Reader) ([]VirtualServer, error) {
+	virtualServers := make([]VirtualServer, 0)
+
+	prop := "="
+
+	var (
+		section string
+		vs      *VirtualServer
+		rs      *RealServer
+		rsName  string
+		vsName  string
+		checker *RealServerChecker
+	)
+
+	// health checkers are listed after the LVS topology, so they're attached once parsed
+	addChecker := func() {
+		if checker == nil {
+			return
+		}
+
+		attached := false
+
+		for idx := range virtualServers {
+			// virtual server of checkers is not dumped by older releases, so real servers of all are searched
+			if vsName != "" && virtualServers[idx].Name != vsName {
+				continue
+			}
+
+			if attached = virtualServers[idx].addChecker(rsName, *checker); attached {
+				break
+			}
+		}
+
+		if !attached {
+			slog.Warn("Health checker of unknown real server found",
+				"rs", rsName,
+				"vs", vsName,
+				"checker", checker.Type,
+			)
+		}
+
+		checker = nil
+	}
+
+	scanner := bufio.NewScanner(bufio.NewReader(i))
+
+	for scanner.Scan() {
+		l := scanner.Text()
+
+		if strings.HasPrefix(l, "------<") {
+			addChecker()
+
+			section = strings.TrimSpace(strings.Trim(l, "-<>"))
+
+			continue
+		}
+
+		switch section {
+		case "LVS Topology":
+			switch {
+			case strings.HasPrefix(l, "     ") && rs != nil:
+				s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+				if len(s) != 2 {
+					continue
+				}
+
+				key := strings.TrimSpace(s[0])
+				val := strings.TrimSpace(s[1])
+
+				switch key {
+				case "weight":
+					if err := rs.setWeight(val); err != nil {
+						return virtualServers, err
+					}
+				case "alive":
+					rs.Alive = val == "yes"
+				}
+			case strings.HasPrefix(l, "   ") && vs != nil:
+				// properties may be grouped like "quorum = 2, hysteresis = 0"
+				for _, p := range strings.Split(strings.TrimSpace(l), ",") {
+					s := strings.SplitN(strings.TrimSpace(p), prop, 2)
+					if len(s) != 2 {
+						continue
+					}
+
+					key := strings.TrimSpace(s[0])
+					val := strings.TrimSpace(s[1])
+
+					switch key {
+					case "RS":
+						vs.RealServers = append(vs.RealServers, RealServer{Name: val})
+						rs = &vs.RealServers[len(vs.RealServers)-1]
+					case "quorum":
+						if err := vs.setQuorum(val); err != nil {
+							return virtualServers, err
+						}
+					case "Quorum state":
+						vs.QuorumUp = val == "UP"
+					}
+				}
+			case strings.HasPrefix(l, " VS "):
+				s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+				if len(s) != 2 {
+					continue
+				}
+
+				virtualServers = append(virtualServers, VirtualServer{Name: strings.TrimSpace(s[1])})
+				vs = &virtualServers[len(virtualServers)-1]
+				rs = nil
+			}
+		case "Health checkers":
+			switch {
+			case strings.HasPrefix(l, "   ") && checker != nil:
+				s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+				if len(s) != 2 {
+					continue
+				}
+
+				key := strings.TrimSpace(s[0])
+				val := strings.TrimSpace(s[1])
+
+				switch key {
+				case "Virtual server":
+					vsName = val
+				case "Keepalive method":
+					checker.Type = val
+				case "Is UP":
+					checker.Up = val == "yes"
+				}
+			case strings.HasPrefix(l, " ["):
+				addChecker()
+
+				rsName = strings.TrimSpace(l)
+				vsName = ""
+				checker = &RealServerChecker{}
+			}
+		}
+	}
+
+	addChecker()
+
+	return virtualServers, nil
+}
+
+// parseTrackSection calls setProp for properties of each item in "sep = name" format like " Track file = chk_file".
+func parseTrackSection(i io.Reader, sep string, newItem func(name string), setProp func(key, val string) error) error {
+	prop := "="
+	inItem := false
+
+	scanner := bufio.NewScanner(bufio.NewReader(i))
+
+	for scanner.Scan() {
+		l := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(l, " "+sep) && strings.Contains(l, prop):
+			s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+			newItem(strings.TrimSpace(s[1]))
+
+			inItem = true
+		case strings.HasPrefix(l, "     "):
+			continue
+		case strings.HasPrefix(l, "   ") && inItem:
+			s := strings.SplitN(strings.TrimSpace(l), prop, 2)
+			if len(s) != 2 {
+				continue
+			}
+
+			if err := setProp(strings.TrimSpace(s[0]), strings.TrimSpace(s[1])); err != nil {
+				return err
+			}
+		default:
+			inItem = false
+		}
+	}
+
+	return nil
+}
+
+// ParseVRRPTrackFiles parses vrrp_track_file sections of keepalived.data.
+func ParseVRRPTrackFiles(i io.Reader) ([]VRRPTrackFile, error) {
+	files := make([]VRRPTrackFile, 0)
+
+	err := parseTrackSection(i, "Track file",
+		func(name string) {
+			files = append(files, VRRPTrackFile{Name: name})
+		},
+		func(key, val string) error {
+			file := &files[len(files)-1]
+
+			switch key {
+			case "File":
+				file.Path = val
+			case "Status":
+				return file.setValue(val)
+			case "Weight":
+				return file.setWeight(val)
+			}
+
+			return nil
+		},
+	)
+
+	return files, err
+}
+
+// ParseVRRPTrackProcesses parses vrrp_track_process sections of keepalived.data.
+func ParseVRRPTrackProcesses(i io.Reader) ([]VRRPTrackProcess, error) {
+	processes := make([]VRRPTrackProcess, 0)
+
+	err := parseTrackSection(i, "Track process",
+		func(name string) {
+			processes = append(processes, VRRPTrackProcess{Name: name})
+		},
+		func(key, val string) error {
+			process := &processes[len(processes)-1]
+
+			switch key {
+			case "Process":
+				process.Process = val
+			case "Current processes":
+				return process.setCount(val)
+			case "Have quorum":
+				process.Up = val == "true"
+			case "Weight":
+				return process.setWeight(val)
+			}
+
+			return nil
+		},
+	)
+
+	return processes, err
+}
+
+// ParseBFDData parses BFD instances of keepalived_bfd.data.
+func ParseBFDData(i io.Reader) ([]BFDInstance, error) {
+	bfds := make([]BFDInstance, 0)
+
+	err := parseTrackSection(i, "BFD Instance",
+		func(name string) {
+			bfds = append(bfds, BFDInstance{Name: name})
+		},
+		func(key, val string) error {
+			bfd := &bfds[len(bfds)-1]
+
+			switch key {
+			case "Neighbor IP":
+				bfd.Neighbor = val
+			case "Local state":
+				return bfd.setLocalState(val)
+			case "Remote state":
+				return bfd.setRemoteState(val)
+			case "Local discriminator":
+				return bfd.setDiscriminator(&bfd.LocalDiscriminator, val)
+			case "Remote discriminator":
+				return bfd.setDiscriminator(&bfd.RemoteDiscriminator, val)
+			case "State transitions":
+				return bfd.setTransitions(val)
+			}
+
+			return nil
+		},
+	)
+
+	return bfds, err
+}
+
 func ParseStats(i io.Reader) (map[string]*VRRPStats, error) {
 	stats := make(map[string]*VRRPStats)
 
@@ -312,17 +917,178 @@ func ParseStats(i io.Reader) (map[string]*VRRPStats, error) {
 	return stats, nil
 }
 
-func ParseVIP(vip string) (string, string, bool) {
-	args := strings.Split(vip, " ")
-	if len(args) < 3 {
-		slog.Error("Failed to parse VIP from keepalived data",
+// ParseVIP parses a VIP of keepalived data like "10.0.0.100/24 brd 10.0.0.255 dev eth0 scope global label eth0:1 set".
+// On error the returned VIP only holds its raw address.
+func ParseVIP(vip string) (VIP, error) {
+	args := strings.Fields(vip)
+	if len(args) == 0 {
+		return VIP{}, errors.New("empty VIP")
+	}
+
+	raw := VIP{Raw: args[0]}
+	addr, prefix, hasPrefix := strings.Cut(args[0], "/")
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return raw, fmt.Errorf("invalid VIP address: %s", vip)
+	}
+
+	v := VIP{Raw: args[0], Address: ip.String(), Family: "ipv4", Prefix: 8 * net.IPv4len}
+	if ip.To4() == nil {
+		v.Family = "ipv6"
+		v.Prefix = 8 * net.IPv6len
+	}
+
+	if hasPrefix {
+		var err error
+		if v.Prefix, err = strconv.Atoi(prefix); err != nil {
+			return raw, fmt.Errorf("invalid VIP prefix: %s", vip)
+		}
+	}
+
+	for idx := 1; idx < len(args); idx++ {
+		key := args[idx]
+
+		// flags like "set", "home" or "-nodad" have no value
+		if !slices.Contains([]string{"dev", "scope", "label", "brd", "peer"}, key) {
+			v.Set = v.Set || key == "set"
+
+			continue
+		}
+
+		if idx+1 >= len(args) {
+			return raw, fmt.Errorf("missing VIP %s value: %s", key, vip)
+		}
+
+		idx++
+
+		switch key {
+		case "dev":
+			v.Device = args[idx]
+		case "scope":
+			v.Scope = args[idx]
+		case "label":
+			v.Label = args[idx]
+		}
+	}
+
+	return v, nil
+}
+
+// UnmarshalJSON decodes a VIP from its keepalived data representation.
+func (v *VIP) UnmarshalJSON(b []byte) error {
+	var vip string
+	if err := json.Unmarshal(b, &vip); err != nil {
+		return err
+	}
+
+	var err error
+	if *v, err = ParseVIP(vip); err != nil {
+		// a single malformed VIP must not fail the whole JSON dump
+		slog.Warn("Failed to parse VIP from keepalived json, keeping its raw address",
 			"VIP", vip,
-			"ExpectedArgs", 3,
-			"GotArgs", len(args),
+			"error", err,
 		)
+	}
+
+	return nil
+}
+
+// routeTables contains well-known routing table names of /etc/iproute2/rt_tables.
+var routeTables = map[string]int{"default": 253, "main": 254, "local": 255}
+
+// parseRouteTable converts a routing table name or number to its number.
+func parseRouteTable(table string) (int, error) {
+	if id, ok := routeTables[table]; ok {
+		return id, nil
+	}
+
+	return strconv.Atoi(table)
+}
+
+// normalizePrefix converts an address with optional prefix length to its network like "10.0.0.0/24".
+// "default", "all" and zero length prefixes are returned as unspecified.
+func normalizePrefix(prefix, unspecified string) (string, error) {
+	if prefix == "" || prefix == "default" || prefix == "all" {
+		return unspecified, nil
+	}
+
+	if !strings.Contains(prefix, "/") {
+		ip := net.ParseIP(prefix)
+		if ip == nil {
+			return "", fmt.Errorf("invalid address: %s", prefix)
+		}
+
+		return ipNetString(&net.IPNet{IP: ip, Mask: net.CIDRMask(8*len(ip.To16()), 8*len(ip.To16()))}, unspecified), nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	return ipNetString(ipNet, unspecified), nil
+}
+
+// addressFamily returns ipv4 or ipv6 of an address with optional prefix length, or empty string when it is not an address.
+func addressFamily(addr string) string {
+	ip := net.ParseIP(strings.Split(addr, "/")[0])
+
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return "ipv4"
+	default:
+		return "ipv6"
+	}
+}
+
+// ipNetString returns the network in CIDR notation or unspecified when it is nil or has zero length prefix.
+func ipNetString(ipNet *net.IPNet, unspecified string) string {
+	if ipNet == nil {
+		return unspecified
+	}
+
+	if ones, _ := ipNet.Mask.Size(); ones == 0 {
+		return unspecified
+	}
+
+	if ip4 := ipNet.IP.To4(); ip4 != nil && len(ipNet.Mask) == net.IPv6len {
+		ipNet = &net.IPNet{IP: ip4, Mask: ipNet.Mask[net.IPv6len-net.IPv4len:]}
+	}
+
+	return ipNet.String()
+}
+
+// matches checks if the kernel route has the destination, family, nexthop, metric and table of the virtual route.
+func (r VirtualRoute) matches(kernel VirtualRoute) bool {
+	return r.Dst == kernel.Dst &&
+		(r.Family == "" || r.Family == kernel.Family) &&
+		(r.Via == "" || r.Via == kernel.Via) &&
+		(r.Metric == 0 || r.Metric == kernel.Metric) &&
+		(r.Table == 0 || r.Table == kernel.Table)
+}
+
+// matches checks if the kernel rule has the selectors and table of the virtual rule.
+func (r VirtualRule) matches(kernel VirtualRule) bool {
+	return r.From == kernel.From && r.To == kernel.To && (r.Table == 0 || r.Table == kernel.Table)
+}
+
+// ipAddress returns the VIP address with its prefix length unless it's a host address.
+func (v VIP) ipAddress() string {
+	if (v.Family == "ipv4" && v.Prefix == 8*net.IPv4len) || (v.Family == "ipv6" && v.Prefix == 8*net.IPv6len) {
+		return v.Address
+	}
+
+	return v.Address + "/" + strconv.Itoa(v.Prefix)
+}
 
-		return "", "", false
+// intf returns the VIP device or the VRRP instance interface when VIP has no device.
+func (v VIP) intf(instanceIntf string) string {
+	if v.Device == "" {
+		return instanceIntf
 	}
 
-	return args[0], args[2], true
+	return v.Device
 }
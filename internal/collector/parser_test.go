@@ -3,6 +3,7 @@ package collector
 import (
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -83,31 +84,61 @@ func TestV215ParseVRRPData(t *testing.T) {
 	}
 
 	viExt1 := VRRPData{
-		IName:     "VI_EXT_1",
-		State:     2,
-		WantState: 2,
-		Intf:      "ens192",
-		GArpDelay: 5,
-		VRID:      10,
-		VIPs:      []string{"192.168.2.1 dev ens192 scope global set"},
+		IName:             "VI_EXT_1",
+		State:             2,
+		WantState:         2,
+		Intf:              "ens192",
+		GArpDelay:         5,
+		VRID:              10,
+		Priority:          100,
+		EffectivePriority: 100,
+		TotalPriority:     100,
+		LastTransition:    1594831166.420598,
+		ReadTimeout:       1594995529.942882,
+		AdvertInterval:    1,
+		MasterDownTimer:   0.608848,
+		VIPs:              []VIP{{Raw: "192.168.2.1", Address: "192.168.2.1", Prefix: 32, Family: "ipv4", Device: "ens192", Scope: "global", Set: true}},
+		TrackedScripts:    []VRRPTrackedScript{{Name: "check_script", Weight: -60}},
+		SrcIP:             "192.168.1.1",
+		UnicastPeers:      []string{"192.168.1.2", "192.168.1.3"},
 	}
 	viExt2 := VRRPData{
-		IName:     "VI_EXT_2",
-		State:     1,
-		WantState: 1,
-		Intf:      "ens192",
-		GArpDelay: 5,
-		VRID:      20,
-		VIPs:      []string{"192.168.2.2 dev ens192 scope global"},
+		IName:             "VI_EXT_2",
+		State:             1,
+		WantState:         1,
+		Intf:              "ens192",
+		GArpDelay:         5,
+		VRID:              20,
+		Priority:          80,
+		EffectivePriority: 80,
+		TotalPriority:     80,
+		LastTransition:    1594974363.398961,
+		ReadTimeout:       1594995532.22548,
+		AdvertInterval:    1,
+		MasterDownTimer:   3.6875,
+		VIPs:              []VIP{{Raw: "192.168.2.2", Address: "192.168.2.2", Prefix: 32, Family: "ipv4", Device: "ens192", Scope: "global"}},
+		TrackedScripts:    []VRRPTrackedScript{{Name: "check_script", Weight: -60}},
+		SrcIP:             "192.168.1.1",
+		UnicastPeers:      []string{"192.168.1.2", "192.168.1.3"},
 	}
 	viExt3 := VRRPData{
-		IName:     "VI_EXT_3",
-		State:     1,
-		WantState: 1,
-		Intf:      "ens192",
-		GArpDelay: 5,
-		VRID:      30,
-		VIPs:      []string{"192.168.2.3 dev ens192 scope global"},
+		IName:             "VI_EXT_3",
+		State:             1,
+		WantState:         1,
+		Intf:              "ens192",
+		GArpDelay:         5,
+		VRID:              30,
+		Priority:          90,
+		EffectivePriority: 90,
+		TotalPriority:     90,
+		LastTransition:    1594974363.374509,
+		ReadTimeout:       1594995532.381775,
+		AdvertInterval:    1,
+		MasterDownTimer:   3.648437,
+		VIPs:              []VIP{{Raw: "192.168.2.3", Address: "192.168.2.3", Prefix: 32, Family: "ipv4", Device: "ens192", Scope: "global"}},
+		TrackedScripts:    []VRRPTrackedScript{{Name: "check_script", Weight: -60}},
+		SrcIP:             "192.168.1.1",
+		UnicastPeers:      []string{"192.168.1.2", "192.168.1.3"},
 	}
 
 	for _, data := range vrrpData {
@@ -149,13 +180,22 @@ func TestV2010ParseVRRPData(t *testing.T) {
 	}
 
 	vi1 := VRRPData{
-		IName:     "VI_1",
-		State:     2,
-		WantState: 2,
-		Intf:      "ens192",
-		GArpDelay: 5,
-		VRID:      52,
-		VIPs:      []string{"2.2.2.2/32 dev ens192 scope global"},
+		IName:             "VI_1",
+		State:             2,
+		WantState:         2,
+		Intf:              "ens192",
+		GArpDelay:         5,
+		VRID:              52,
+		Priority:          50,
+		EffectivePriority: 50,
+		TotalPriority:     50,
+		LastTransition:    1595875667,
+		ReadTimeout:       1596022954.764907,
+		AdvertInterval:    1,
+		MasterDownTimer:   0.804687,
+		VIPs:              []VIP{{Raw: "2.2.2.2/32", Address: "2.2.2.2", Prefix: 32, Family: "ipv4", Device: "ens192", Scope: "global"}},
+		TrackedScripts:    []VRRPTrackedScript{{Name: "chk_service", Weight: 0}},
+		SrcIP:             "1.1.1.1",
 	}
 
 	for _, data := range vrrpData {
@@ -190,6 +230,10 @@ func TestV215ParseVRRPScript(t *testing.T) {
 			t.Fail()
 		}
 
+		if !reflect.DeepEqual(script.Instances, []VRRPScriptInstance{{IName: "VI_1", Weight: 0}}) {
+			t.Fail()
+		}
+
 		if script.Status != "GOOD" {
 			t.Fail()
 		}
@@ -221,6 +265,23 @@ func TestV2010ParseVRRPScript(t *testing.T) {
 			t.Fail()
 		}
 
+		if script.Command != "'/etc/keepalived/script.sh'" || script.Interval != 2 || script.Weight != -60 {
+			t.Fail()
+		}
+
+		if script.Rise != 5 || script.Fall != 1 {
+			t.Fail()
+		}
+
+		expectedInstances := []VRRPScriptInstance{
+			{IName: "VI_EXT_1", Weight: -60},
+			{IName: "VI_EXT_2", Weight: -60},
+			{IName: "VI_EXT_3", Weight: -60},
+		}
+		if !reflect.DeepEqual(script.Instances, expectedInstances) {
+			t.Fail()
+		}
+
 		if script.Status != "GOOD" {
 			t.Fail()
 		}
@@ -336,13 +397,17 @@ func TestV135ParseVRRPData(t *testing.T) {
 	}
 
 	vi1 := VRRPData{
-		IName:     "VI_1",
-		State:     2,
-		WantState: 0,
-		Intf:      "eth0",
-		GArpDelay: 5,
-		VRID:      51,
-		VIPs:      []string{"10.32.75.200/32 dev eth0 scope global"},
+		IName:          "VI_1",
+		State:          2,
+		WantState:      0,
+		Intf:           "eth0",
+		GArpDelay:      5,
+		VRID:           51,
+		Priority:       150,
+		LastTransition: 1596892296,
+		AdvertInterval: 1,
+		VIPs:           []VIP{{Raw: "10.32.75.200/32", Address: "10.32.75.200", Prefix: 32, Family: "ipv4", Device: "eth0", Scope: "global"}},
+		SrcIP:          "192.168.2.2",
 	}
 
 	for _, data := range vrrpData {
@@ -377,6 +442,10 @@ func TestV135ParseVRRPScript(t *testing.T) {
 			t.Fail()
 		}
 
+		if script.Command != "/etc/keepalived/check.sh" || script.Interval != 2 || script.Rise != 1 {
+			t.Fail()
+		}
+
 		if script.Status != "BAD" {
 			t.Fail()
 		}
@@ -428,28 +497,78 @@ func TestV135ParseStats(t *testing.T) {
 	}
 }
 
+func TestParseJSONBadVIP(t *testing.T) {
+	t.Parallel()
+
+	vrrps, err := ParseJSON(strings.NewReader(`[{"data": {"iname": "VI_1", "vips": [
+		"10.0.0.100/24 dev eth0 scope global",
+		"10.0.0.300/24 dev eth0 scope global"
+	]}}]`))
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := []VIP{
+		{Raw: "10.0.0.100/24", Address: "10.0.0.100", Prefix: 24, Family: "ipv4", Device: "eth0", Scope: "global"},
+		{Raw: "10.0.0.300/24"},
+	}
+	if len(vrrps) != 1 || !reflect.DeepEqual(vrrps[0].Data.VIPs, expected) {
+		t.Fail()
+	}
+}
+
 func TestParseVIP(t *testing.T) {
 	t.Parallel()
 
-	vips := []string{"192.168.2.2 dev ens192 scope global", "192.168.2.2 dev ens192 scope global set"}
-	excpectedIP := "192.168.2.2"
-	excpectedIntf := "ens192"
+	testCases := []struct {
+		vip      string
+		expected VIP
+	}{
+		{
+			vip:      "192.168.2.2 dev ens192 scope global",
+			expected: VIP{Raw: "192.168.2.2", Address: "192.168.2.2", Prefix: 32, Family: "ipv4", Device: "ens192", Scope: "global"},
+		},
+		{
+			vip:      "192.168.2.2/24 brd 192.168.2.255 dev ens192 scope global label ens192:1 set",
+			expected: VIP{Raw: "192.168.2.2/24", Address: "192.168.2.2", Prefix: 24, Family: "ipv4", Device: "ens192", Scope: "global", Label: "ens192:1", Set: true},
+		},
+		{
+			vip:      "fd00::100/64 dev eth0 scope global -nodad set",
+			expected: VIP{Raw: "fd00::100/64", Address: "fd00::100", Prefix: 64, Family: "ipv6", Device: "eth0", Scope: "global", Set: true},
+		},
+		{
+			vip:      "fe80::1 scope link",
+			expected: VIP{Raw: "fe80::1", Address: "fe80::1", Prefix: 128, Family: "ipv6", Scope: "link"},
+		},
+	}
 
-	for _, vip := range vips {
-		ip, intf, ok := ParseVIP(vip)
-		if !ok {
-			t.Error("Error parsing")
+	for _, tc := range testCases {
+		vip, err := ParseVIP(tc.vip)
+		if err != nil || !reflect.DeepEqual(vip, tc.expected) {
+			t.Log(tc.vip)
 			t.Fail()
 		}
+	}
 
-		if ip != excpectedIP || intf != excpectedIntf {
-			t.Error("ip or interface not equals")
+	for _, badVIP := range []string{"", "192.168.2.2 dev", "192.168.2.300 dev eth0", "192.168.2.2/ab dev eth0"} {
+		if _, err := ParseVIP(badVIP); err == nil {
+			t.Log(badVIP)
 			t.Fail()
 		}
 	}
+}
 
-	badVIP := "192.168.2.2 dev"
-	if ip, intf, ok := ParseVIP(badVIP); ok || ip != "" || intf != "" {
+func TestVIPLabels(t *testing.T) {
+	t.Parallel()
+
+	host := VIP{Raw: "10.0.0.1/32", Address: "10.0.0.1", Prefix: 32, Family: "ipv4"}
+	if host.ipAddress() != "10.0.0.1" || host.intf("eth0") != "eth0" {
+		t.Fail()
+	}
+
+	subnet := VIP{Raw: "fd00::100/64", Address: "fd00::100", Prefix: 64, Family: "ipv6", Device: "eth1"}
+	if subnet.ipAddress() != "fd00::100/64" || subnet.intf("eth0") != "eth1" {
 		t.Fail()
 	}
 }
@@ -457,7 +576,7 @@ func TestParseVIP(t *testing.T) {
 func TestIsKeyArray(t *testing.T) {
 	t.Parallel()
 
-	supportedKeys := []string{"Virtual IP"}
+	supportedKeys := []string{"Virtual IP", "Tracked scripts", "Tracked interfaces", "Unicast Peer"}
 
 	for _, key := range supportedKeys {
 		if !isKeyArray(key) {
@@ -491,14 +610,25 @@ func TestV227ParseVRRPData(t *testing.T) {
 	}
 
 	viExt1 := VRRPData{
-		IName:        "VI_227_1",
-		State:        2,
-		WantState:    2,
-		Intf:         "ens3",
-		GArpDelay:    5,
-		VRID:         52,
-		VIPs:         []string{"10.1.0.1/24 dev ens3 scope global set"},
-		ExcludedVIPs: []string{"10.10.0.1 dev ens3 scope global set"},
+		IName:             "VI_227_1",
+		State:             2,
+		WantState:         2,
+		Intf:              "ens3",
+		GArpDelay:         5,
+		VRID:              52,
+		Priority:          50,
+		EffectivePriority: 150,
+		TotalPriority:     150,
+		LastTransition:    1673674892.348360,
+		ReadTimeout:       1674563074.242128,
+		AdvertInterval:    4,
+		MasterDownTimer:   1.65625,
+		DownTimerAdverts:  3,
+		VIPs:              []VIP{{Raw: "10.1.0.1/24", Address: "10.1.0.1", Prefix: 24, Family: "ipv4", Device: "ens3", Scope: "global", Set: true}},
+		ExcludedVIPs:      []VIP{{Raw: "10.10.0.1", Address: "10.10.0.1", Prefix: 32, Family: "ipv4", Device: "ens3", Scope: "global", Set: true}},
+		TrackedScripts:    []VRRPTrackedScript{{Name: "chk_script", Weight: 100}},
+		SrcIP:             "10.1.0.166",
+		McastGroup:        "224.0.0.18",
 	}
 
 	for _, data := range vrrpData {
@@ -509,3 +639,690 @@ func TestV227ParseVRRPData(t *testing.T) {
 		}
 	}
 }
+
+func TestV227ParseJSON(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.7/keepalived.json")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	vrrps, err := ParseJSON(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	if len(vrrps) != 1 {
+		t.FailNow()
+	}
+
+	viExt1 := VRRPData{
+		IName:             "VI_227_1",
+		State:             2,
+		WantState:         2,
+		Intf:              "ens3",
+		GArpDelay:         5,
+		VRID:              52,
+		Priority:          50,
+		EffectivePriority: 150,
+		LastTransition:    1673674892.348360,
+		AdvertInterval:    4,
+		VIPs:              []VIP{{Raw: "10.1.0.1/24", Address: "10.1.0.1", Prefix: 24, Family: "ipv4", Device: "ens3", Scope: "global", Set: true}},
+		ExcludedVIPs:      []VIP{{Raw: "10.10.0.1", Address: "10.10.0.1", Prefix: 32, Family: "ipv4", Device: "ens3", Scope: "global", Set: true}},
+		TrackedScripts:    []VRRPTrackedScript{{Name: "chk_script"}},
+	}
+	if !reflect.DeepEqual(vrrps[0].Data, viExt1) {
+		t.Fail()
+	}
+
+	if vrrps[0].Stats.AdvertRcvd != 11 || vrrps[0].Stats.BecomeMaster != 2 {
+		t.Fail()
+	}
+}
+
+func TestParseSeconds(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value    string
+		expected float64
+		ok       bool
+	}{
+		{value: "1 sec", expected: 1, ok: true},
+		{value: "4000 milli-sec", expected: 4, ok: true},
+		{value: "1656250 usecs", expected: 1.65625, ok: true},
+		{value: "1 hour", expected: 0, ok: false},
+		{value: "NA sec", expected: 0, ok: false},
+		{value: "1", expected: 0, ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := parseSeconds(tc.value)
+			if (err == nil) != tc.ok || result != tc.expected {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestV228ParseVRRPDataFaults(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived_fault.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	vrrpData, err := ParseVRRPData(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	if len(vrrpData) != 2 {
+		t.Fail()
+	}
+
+	viFault := VRRPData{
+		IName:             "VI_FAULT",
+		State:             3,
+		WantState:         1,
+		Intf:              "eth0",
+		GArpDelay:         5,
+		VRID:              61,
+		Priority:          100,
+		EffectivePriority: 70,
+		TotalPriority:     70,
+		LastTransition:    1700570000.25,
+		ReadTimeout:       1700656400.45,
+		ConfigFaults:      1,
+		TrackFaults:       2,
+		TrackScriptsInit:  1,
+		AdvertInterval:    4,
+		MasterDownTimer:   12.15625,
+		DownTimerAdverts:  3,
+		VIPs:              []VIP{{Raw: "10.0.1.100/24", Address: "10.0.1.100", Prefix: 24, Family: "ipv4", Device: "eth0", Scope: "global"}},
+		TrackedScripts:    []VRRPTrackedScript{{Name: "chk_haproxy"}, {Name: "chk_nginx"}},
+		TrackedInterfaces: []VRRPTrackedInterface{{Name: "eth1", Weight: -30}},
+		SrcIP:             "10.0.1.11",
+		McastGroup:        "224.0.0.18",
+	}
+
+	if !reflect.DeepEqual(*vrrpData["VI_FAULT"], viFault) {
+		t.Log(*vrrpData["VI_FAULT"])
+		t.Fail()
+	}
+
+	viPeer := vrrpData["VI_PEER"]
+	if viPeer.State != 1 || viPeer.WantState != 1 || viPeer.ConfigFaults != 0 || viPeer.TrackFaults != 0 || viPeer.TrackScriptsInit != 0 {
+		t.Fail()
+	}
+}
+
+func TestV228ParseVRRPData(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	vrrpData, err := ParseVRRPData(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	if len(vrrpData) != 2 {
+		t.Fail()
+	}
+
+	vi1 := VRRPData{
+		IName:             "VI_1",
+		State:             2,
+		WantState:         2,
+		Intf:              "eth0",
+		GArpDelay:         5,
+		VRID:              51,
+		Priority:          100,
+		EffectivePriority: 100,
+		TotalPriority:     100,
+		LastTransition:    1700568013.513213,
+		ReadTimeout:       1700654413.713213,
+		AdvertInterval:    1,
+		MasterDownTimer:   3.609375,
+		DownTimerAdverts:  3,
+		VIPs: []VIP{
+			{Raw: "10.0.0.100/24", Address: "10.0.0.100", Prefix: 24, Family: "ipv4", Device: "eth0", Scope: "global", Set: true},
+			{Raw: "10.0.0.101/24", Address: "10.0.0.101", Prefix: 24, Family: "ipv4", Device: "eth0", Scope: "global", Set: true},
+		},
+		TrackedScripts: []VRRPTrackedScript{{Name: "chk_haproxy", Weight: -30}},
+		TrackedBFDs:    []VRRPTrackedBFD{{Name: "bfd_gw"}},
+		TrackedInterfaces: []VRRPTrackedInterface{
+			{Name: "eth1", Weight: 50, Status: "UP"},
+			{Name: "eth2", Weight: -20, Status: "DOWN"},
+		},
+		SrcIP:      "10.0.0.11",
+		McastGroup: "224.0.0.18",
+		VirtualRoutes: []VirtualRoute{
+			{Dst: "192.168.100.0/24", Family: "ipv4", Via: "10.0.0.1", Table: 100},
+			{Dst: "default", Family: "ipv4", Via: "10.0.0.1", Table: 254},
+		},
+		VirtualRules: []VirtualRule{{From: "10.0.0.100/32", To: "all", Table: 100}},
+	}
+
+	vi2 := VRRPData{
+		IName:             "VI_2",
+		State:             2,
+		WantState:         2,
+		Intf:              "eth0",
+		GArpDelay:         5,
+		VRID:              52,
+		Priority:          100,
+		EffectivePriority: 100,
+		TotalPriority:     100,
+		LastTransition:    1700568013.514002,
+		ReadTimeout:       1700654413.714002,
+		AdvertInterval:    1,
+		MasterDownTimer:   3.609375,
+		DownTimerAdverts:  3,
+		VIPs:              []VIP{{Raw: "fd00::100/64", Address: "fd00::100", Prefix: 64, Family: "ipv6", Device: "eth0", Scope: "global", Set: true}},
+		SrcIP:             "fd00::11",
+		UnicastPeers:      []string{"fd00::12", "fd00::13"},
+	}
+
+	if !reflect.DeepEqual(*vrrpData["VI_1"], vi1) {
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(*vrrpData["VI_2"], vi2) {
+		t.Fail()
+	}
+}
+
+func TestV228ParseVRRPScript(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	vrrpScripts := ParseVRRPScript(f)
+
+	expected := []VRRPScript{
+		{
+			Name:      "chk_haproxy",
+			Command:   "'/usr/bin/killall' '-0' 'haproxy'",
+			Interval:  2,
+			Timeout:   0,
+			Weight:    -30,
+			Rise:      2,
+			Fall:      3,
+			Result:    2,
+			InitState: "good",
+			Status:    "GOOD",
+			State:     "idle",
+			Instances: []VRRPScriptInstance{{IName: "VI_1", Weight: -30}},
+		},
+	}
+	if !reflect.DeepEqual(vrrpScripts, expected) {
+		t.Fail()
+	}
+}
+
+func TestV228ParseVRRPTrackFiles(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	trackFiles, err := ParseVRRPTrackFiles(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := []VRRPTrackFile{
+		{Name: "chk_maintenance", Path: "/etc/keepalived/maintenance", Value: 0, Weight: 10},
+		{Name: "chk_drain", Path: "/etc/keepalived/drain", Value: -254, Weight: 1},
+	}
+	if !reflect.DeepEqual(trackFiles, expected) {
+		t.Log(trackFiles)
+		t.Fail()
+	}
+}
+
+func TestV228ParseVRRPTrackProcesses(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	trackProcesses, err := ParseVRRPTrackProcesses(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := []VRRPTrackProcess{
+		{Name: "chk_nginx", Process: "/usr/sbin/nginx", Count: 2, Up: true, Weight: 0},
+		{Name: "chk_bird", Process: "bird", Count: 0, Up: false, Weight: -20},
+	}
+	if !reflect.DeepEqual(trackProcesses, expected) {
+		t.Log(trackProcesses)
+		t.Fail()
+	}
+}
+
+func TestV228ParseBFDData(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived_bfd.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	bfds, err := ParseBFDData(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := []BFDInstance{
+		{
+			Name:                "bfd_gw",
+			Neighbor:            "10.0.0.1",
+			LocalState:          3,
+			RemoteState:         3,
+			LocalDiscriminator:  0x1a2b3c4d,
+			RemoteDiscriminator: 0x5e6f7a8b,
+			Transitions:         3,
+		},
+		{
+			Name:               "bfd_peer",
+			Neighbor:           "fd00::12",
+			LocalState:         1,
+			RemoteState:        0,
+			LocalDiscriminator: 2,
+		},
+	}
+	if !reflect.DeepEqual(bfds, expected) {
+		t.Log(bfds)
+		t.Fail()
+	}
+}
+
+func TestBFDSetters(t *testing.T) {
+	t.Parallel()
+
+	bfd := &BFDInstance{Name: "bfd_gw"}
+
+	if err := bfd.setLocalState("Unknown"); err == nil {
+		t.Fail()
+	}
+
+	if err := bfd.setDiscriminator(&bfd.LocalDiscriminator, "0x1ffffffff"); err == nil {
+		t.Fail()
+	}
+
+	if err := bfd.setTransitions("NA"); err == nil {
+		t.Fail()
+	}
+
+	vrrp := &VRRPData{IName: "VI_1"}
+	if err := vrrp.addTrackedBFD("bfd_gw: weight -10 reverse"); err != nil {
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(vrrp.TrackedBFDs, []VRRPTrackedBFD{{Name: "bfd_gw", Weight: -10}}) {
+		t.Fail()
+	}
+}
+
+func TestParseIntfStatus(t *testing.T) {
+	t.Parallel()
+
+	statuses := map[string]string{
+		"UP, RUNNING":         "UP",
+		" UP":                 "UP",
+		"DOWN":                "DOWN",
+		"not UP, not RUNNING": "DOWN",
+	}
+
+	for state, expected := range statuses {
+		if parseIntfStatus(state) != expected {
+			t.Fail()
+		}
+	}
+}
+
+func TestV228ParseVRRPSyncGroups(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	groups, err := ParseVRRPSyncGroups(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := []VRRPSyncGroup{
+		{
+			Name:      "VG_1",
+			State:     2,
+			Instances: []string{"VI_1", "VI_2"},
+			NotifyScripts: map[string]string{
+				"master": "'/etc/keepalived/notify.sh master'",
+				"backup": "'/etc/keepalived/notify.sh backup'",
+				"fault":  "'/etc/keepalived/notify.sh fault'",
+			},
+		},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fail()
+	}
+}
+
+func TestV215ParseVRRPSyncGroups(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.1.5/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	groups, err := ParseVRRPSyncGroups(f)
+	if err != nil || len(groups) != 0 {
+		t.Fail()
+	}
+}
+
+func TestV228ParseGlobalDefinitions(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	global, err := ParseGlobalDefinitions(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := GlobalDefinitions{
+		RouterID:          "lb1",
+		InstanceName:      "lb",
+		Namespace:         "(default)",
+		DynamicInterfaces: false,
+		ScriptSecurity:    false,
+		StartupTime:       1700568010.102443,
+		ConfigLoadTime:    1700568012.901122,
+	}
+	if !reflect.DeepEqual(*global, expected) {
+		t.Fail()
+	}
+}
+
+func TestV215ParseGlobalDefinitions(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.1.5/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	global, err := ParseGlobalDefinitions(f)
+	if err != nil || !reflect.DeepEqual(*global, GlobalDefinitions{}) {
+		t.Fail()
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	t.Parallel()
+
+	if ts, err := parseTimestamp("1673674892.348360 (Sat Jan 14 06:41:32.348360 2023)"); err != nil || ts != 1673674892.348360 {
+		t.Fail()
+	}
+
+	if _, err := parseTimestamp(""); err == nil {
+		t.Fail()
+	}
+
+	if _, err := parseTimestamp("NA"); err == nil {
+		t.Fail()
+	}
+}
+
+func TestV228ParseCheckerData(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived_check.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	virtualServers, err := ParseCheckerData(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := []VirtualServer{
+		{
+			Name:     "[10.0.0.100]:tcp:80",
+			Quorum:   2,
+			QuorumUp: true,
+			RealServers: []RealServer{
+				{
+					Name:   "[192.168.1.11]:tcp:80",
+					Weight: 1,
+					Alive:  true,
+					Checkers: []RealServerChecker{
+						{Type: "HTTP_GET", Up: true},
+						{Type: "MISC_CHECK", Up: true},
+					},
+				},
+				{
+					Name:     "[192.168.1.12]:tcp:80",
+					Weight:   1,
+					Alive:    true,
+					Checkers: []RealServerChecker{{Type: "HTTP_GET", Up: true}},
+				},
+				{
+					Name:     "[192.168.1.13]:tcp:80",
+					Weight:   0,
+					Alive:    false,
+					Checkers: []RealServerChecker{{Type: "HTTP_GET", Up: false}},
+				},
+			},
+		},
+		{
+			Name:     "[fd00::100]:tcp:443",
+			Quorum:   1,
+			QuorumUp: false,
+			RealServers: []RealServer{
+				{
+					Name:     "[fd00::21]:tcp:443",
+					Weight:   3,
+					Alive:    false,
+					Checkers: []RealServerChecker{{Type: "TCP_CHECK", Up: false}, {Type: "TCP_CHECK", Up: true}},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(virtualServers, expected) {
+		t.Fail()
+	}
+}
+
+func TestParseCheckerDataWithoutVirtualServer(t *testing.T) {
+	t.Parallel()
+
+	virtualServers, err := ParseCheckerData(strings.NewReader(`------< LVS Topology >------
+ VS = [10.0.0.100]:tcp:80
+   RS = [192.168.1.11]:tcp:80
+     weight = 1
+ VS = [10.0.0.200]:tcp:80
+   RS = [192.168.1.21]:tcp:80
+     weight = 1
+------< Health checkers >------
+ [192.168.1.21]:tcp:80
+   Keepalive method = TCP_CHECK
+   Is UP = yes
+ [192.168.1.99]:tcp:80
+   Keepalive method = TCP_CHECK
+   Is UP = yes
+`))
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	if len(virtualServers) != 2 || len(virtualServers[0].RealServers[0].Checkers) != 0 {
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(virtualServers[1].RealServers[0].Checkers, []RealServerChecker{{Type: "TCP_CHECK", Up: true}}) {
+		t.Fail()
+	}
+}
+
+func TestV215ParseCheckerData(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.1.5/keepalived.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	virtualServers, err := ParseCheckerData(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	if len(virtualServers) != 0 {
+		t.Fail()
+	}
+}
+
+func TestV228ParseVMACData(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open("../../test_files/v2.2.8/keepalived_vmac.data")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+	defer f.Close() //nolint: errcheck
+
+	vrrpData, err := ParseVRRPData(f)
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := map[string][4]string{
+		"VI_VMAC":   {"vrrp.61, vmac on eth0, xmit base i/f", "eth0", "vrrp.61", "00:00:5e:00:01:3d"},
+		"VI_IPVLAN": {"vrrp.62, ipvlan on eth0", "eth0", "vrrp.62", "52:54:00:12:34:56"},
+	}
+
+	if len(vrrpData) != len(expected) {
+		t.Fail()
+	}
+
+	for iname, intfs := range expected {
+		data, ok := vrrpData[iname]
+		if !ok || [4]string{data.Intf, data.BaseIntf, data.VMACIntf, data.MAC} != intfs {
+			t.Log(iname)
+			t.Fail()
+		}
+	}
+}
+
+func TestNormalizePrefix(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"default":          "any",
+		"all":              "any",
+		"0.0.0.0/0":        "any",
+		"10.0.0.1":         "10.0.0.1/32",
+		"10.0.0.1/24":      "10.0.0.0/24",
+		"fd00::1":          "fd00::1/128",
+		"fd00:1::1/64":     "fd00:1::/64",
+		"::ffff:10.0.0.1":  "10.0.0.1/32",
+		"192.168.100.0/24": "192.168.100.0/24",
+	}
+
+	for prefix, expected := range testCases {
+		if normalized, err := normalizePrefix(prefix, "any"); err != nil || normalized != expected {
+			t.Log(prefix, normalized)
+			t.Fail()
+		}
+	}
+
+	if _, err := normalizePrefix("10.0.0.300", "any"); err == nil {
+		t.Fail()
+	}
+}
+
+func TestParseVRRPDataUnknownDurationUnit(t *testing.T) {
+	t.Parallel()
+
+	data, err := ParseVRRPData(strings.NewReader(` VRRP Instance = VI_1
+   State = MASTER
+   Advert interval = 100 centi-sec
+   Virtual Router ID = 51
+`))
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	if data["VI_1"] == nil || data["VI_1"].VRID != 51 || data["VI_1"].AdvertInterval != 0 {
+		t.Fail()
+	}
+}
@@ -3,6 +3,8 @@ package collector
 import (
 	"fmt"
 	"log/slog"
+	"net"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -63,12 +65,716 @@ func (v *VRRPData) setVRID(vrid string) error {
 	return nil
 }
 
+func (v *VRRPData) setPriority(priority string) error {
+	var err error
+	if v.Priority, err = strconv.Atoi(priority); err != nil {
+		slog.Error("Failed to parse priority to int",
+			"priority", priority,
+			"iname", v.IName,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (v *VRRPData) setEffectivePriority(priority string) error {
+	var err error
+	if v.EffectivePriority, err = strconv.Atoi(priority); err != nil {
+		slog.Error("Failed to parse effective priority to int",
+			"priority", priority,
+			"iname", v.IName,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (v *VRRPData) setTotalPriority(priority string) error {
+	var err error
+	if v.TotalPriority, err = strconv.Atoi(priority); err != nil {
+		slog.Error("Failed to parse total priority to int",
+			"priority", priority,
+			"iname", v.IName,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (v *VRRPData) setConfigFaults(faults string) error {
+	var err error
+	if v.ConfigFaults, err = strconv.Atoi(faults); err != nil {
+		slog.Error("Failed to parse config faults to int",
+			"faults", faults,
+			"iname", v.IName,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (v *VRRPData) setTrackFaults(faults string) error {
+	var err error
+	if v.TrackFaults, err = strconv.Atoi(faults); err != nil {
+		slog.Error("Failed to parse interface and track script faults to int",
+			"faults", faults,
+			"iname", v.IName,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (v *VRRPData) setTrackScriptsInit(scripts string) error {
+	var err error
+	if v.TrackScriptsInit, err = strconv.Atoi(scripts); err != nil {
+		slog.Error("Failed to parse track scripts init to int",
+			"scripts", scripts,
+			"iname", v.IName,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// timers are only exported by optional metrics, so they're skipped rather than failing the whole parse.
+func (v *VRRPData) setAdvertInterval(interval string) {
+	var err error
+	if v.AdvertInterval, err = parseSeconds(interval); err != nil {
+		slog.Warn("Failed to parse advert interval to seconds, skipping it",
+			"interval", interval,
+			"iname", v.IName,
+			"error", err,
+		)
+	}
+}
+
+func (v *VRRPData) setMasterDownTimer(timer string) {
+	var err error
+	if v.MasterDownTimer, err = parseSeconds(timer); err != nil {
+		slog.Warn("Failed to parse master down timer to seconds, skipping it",
+			"timer", timer,
+			"iname", v.IName,
+			"error", err,
+		)
+	}
+}
+
+func (v *VRRPData) setDownTimerAdverts(adverts string) {
+	var err error
+	if v.DownTimerAdverts, err = strconv.Atoi(adverts); err != nil {
+		slog.Warn("Failed to parse down timer adverts to int, skipping it",
+			"adverts", adverts,
+			"iname", v.IName,
+			"error", err,
+		)
+	}
+}
+
+func (v *VRRPData) setReadTimeout(readTimeout string) {
+	var err error
+	if v.ReadTimeout, err = parseTimestamp(readTimeout); err != nil {
+		slog.Warn("Failed to parse read timeout to float, skipping it",
+			"readTimeout", readTimeout,
+			"iname", v.IName,
+			"error", err,
+		)
+	}
+}
+
+func (v *VRRPData) setLastTransition(lastTransition string) error {
+	var err error
+	if v.LastTransition, err = parseTimestamp(lastTransition); err != nil {
+		slog.Error("Failed to parse last transition to float",
+			"lastTransition", lastTransition,
+			"iname", v.IName,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
 func (v *VRRPData) addVIP(vip string) {
-	vip = strings.TrimSpace(vip)
-	v.VIPs = append(v.VIPs, vip)
+	parsed, err := ParseVIP(vip)
+	if err != nil {
+		slog.Warn("Failed to parse VIP from keepalived data, keeping its raw address",
+			"VIP", vip,
+			"iname", v.IName,
+			"error", err,
+		)
+
+		if parsed.Raw == "" {
+			return
+		}
+	}
+
+	v.VIPs = append(v.VIPs, parsed)
 }
 
 func (v *VRRPData) addExcludedVIP(vip string) {
-	vip = strings.TrimSpace(vip)
-	v.ExcludedVIPs = append(v.ExcludedVIPs, vip)
+	parsed, err := ParseVIP(vip)
+	if err != nil {
+		slog.Warn("Failed to parse excluded VIP from keepalived data, keeping its raw address",
+			"VIP", vip,
+			"iname", v.IName,
+			"error", err,
+		)
+
+		if parsed.Raw == "" {
+			return
+		}
+	}
+
+	v.ExcludedVIPs = append(v.ExcludedVIPs, parsed)
+}
+
+func (v *VRRPData) addTrackedScript(script string) error {
+	// value is in "chk_script weight 100" format
+	args := strings.Fields(script)
+	if len(args) == 0 {
+		return nil
+	}
+
+	trackedScript := VRRPTrackedScript{Name: args[0]}
+
+	if len(args) >= 3 && args[1] == "weight" {
+		var err error
+		if trackedScript.Weight, err = strconv.Atoi(args[2]); err != nil {
+			slog.Error("Failed to parse tracked script weight to int",
+				"script", script,
+				"iname", v.IName,
+			)
+
+			return err
+		}
+	}
+
+	v.TrackedScripts = append(v.TrackedScripts, trackedScript)
+
+	return nil
+}
+
+func (v *VRRPData) setSrcIP(srcIP string) {
+	// value may have a suffix like "192.168.1.1 (from configuration)"
+	if args := strings.Fields(srcIP); len(args) > 0 {
+		v.SrcIP = args[0]
+	}
+}
+
+func (v *VRRPData) addUnicastPeer(peer string) {
+	// value is in "192.168.1.2 min_ttl 0 max_ttl 255" format
+	if args := strings.Fields(peer); len(args) > 0 {
+		v.UnicastPeers = append(v.UnicastPeers, args[0])
+	}
+}
+
+// transportMode returns unicast or multicast mode of vrrp advertisements, or empty string when unknown.
+func (v *VRRPData) transportMode() string {
+	switch {
+	case len(v.UnicastPeers) > 0:
+		return "unicast"
+	case v.McastGroup != "":
+		return "multicast"
+	default:
+		return ""
+	}
+}
+
+func (v *VRRPData) addVirtualRoute(route string) {
+	// value is in "192.168.100.0/24 via 10.0.0.1 dev eth0 table 100" format, optionally
+	// prefixed by the route type like "blackhole 10.10.0.0/16"
+	args := strings.Fields(route)
+	if len(args) > 1 && slices.Contains([]string{"unicast", "blackhole", "unreachable", "prohibit", "throw"}, args[0]) {
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return
+	}
+
+	dst, err := normalizePrefix(args[0], "default")
+	if err != nil {
+		slog.Error("Failed to parse virtual route destination",
+			"route", route,
+			"iname", v.IName,
+			"error", err,
+		)
+
+		return
+	}
+
+	vr := VirtualRoute{Dst: dst, Table: routeTables["main"]}
+
+	for idx := 1; idx < len(args)-1; idx++ {
+		var err error
+
+		switch args[idx] {
+		case "table":
+			vr.Table = v.parseRouteTable(route, args[idx+1])
+		case "via":
+			// nexthop may be prefixed by its family like "via inet6 fd00::1"
+			via := args[idx+1]
+			if (via == "inet" || via == "inet6") && idx+2 < len(args) {
+				via = args[idx+2]
+			}
+
+			if ip := net.ParseIP(via); ip != nil {
+				vr.Via = ip.String()
+			} else {
+				err = fmt.Errorf("invalid nexthop: %s", via)
+			}
+		case "metric", "preference", "priority":
+			vr.Metric, err = strconv.Atoi(args[idx+1])
+		}
+
+		if err != nil {
+			slog.Error("Failed to parse virtual route",
+				"route", route,
+				"iname", v.IName,
+				"error", err,
+			)
+
+			return
+		}
+	}
+
+	// default routes take the family of their nexthop
+	if vr.Family = addressFamily(dst); vr.Family == "" {
+		vr.Family = addressFamily(vr.Via)
+	}
+
+	v.VirtualRoutes = append(v.VirtualRoutes, vr)
+}
+
+func (v *VRRPData) addVirtualRule(rule string) {
+	// value is in "from 10.0.0.100 to 10.1.0.0/16 table 100" format
+	args := strings.Fields(rule)
+	vr := VirtualRule{From: "all", To: "all", Table: routeTables["main"]}
+
+	for idx := 0; idx < len(args)-1; idx++ {
+		var err error
+
+		switch args[idx] {
+		case "from":
+			vr.From, err = normalizePrefix(args[idx+1], "all")
+		case "to":
+			vr.To, err = normalizePrefix(args[idx+1], "all")
+		case "table", "lookup":
+			vr.Table = v.parseRouteTable(rule, args[idx+1])
+		}
+
+		if err != nil {
+			slog.Error("Failed to parse virtual rule",
+				"rule", rule,
+				"iname", v.IName,
+				"error", err,
+			)
+
+			return
+		}
+	}
+
+	v.VirtualRules = append(v.VirtualRules, vr)
+}
+
+func (v *VRRPData) parseRouteTable(route, table string) int {
+	id, err := parseRouteTable(table)
+	if err != nil {
+		slog.Warn("Unknown routing table, matching any table",
+			"route", route,
+			"table", table,
+			"iname", v.IName,
+		)
+
+		return 0
+	}
+
+	return id
+}
+
+func (v *VRRPData) addTrackedInterface(intf string) error {
+	// value is in "eth1 weight 50" format
+	args := strings.Fields(intf)
+	if len(args) == 0 {
+		return nil
+	}
+
+	trackedInterface := VRRPTrackedInterface{Name: args[0]}
+
+	if len(args) >= 3 && args[1] == "weight" {
+		var err error
+		if trackedInterface.Weight, err = strconv.Atoi(args[2]); err != nil {
+			slog.Error("Failed to parse tracked interface weight to int",
+				"interface", intf,
+				"iname", v.IName,
+			)
+
+			return err
+		}
+	}
+
+	v.TrackedInterfaces = append(v.TrackedInterfaces, trackedInterface)
+
+	return nil
+}
+
+func (v *VRRPData) setIntf(intf string) {
+	// value is in "vrrp.51, vmac on eth0, xmit base i/f" or "vrrp.52, ipvlan on eth0" format for VMAC and ipvlan.
+	// Intf keeps the whole value as the intf label predates VMAC parsing, which is exported by vmac_info instead.
+	v.Intf = intf
+
+	args := strings.Split(intf, ",")
+	if len(args) < 2 {
+		return
+	}
+
+	kind, base, ok := strings.Cut(strings.TrimSpace(args[1]), " on ")
+	if !ok || (kind != "vmac" && kind != "ipvlan") {
+		return
+	}
+
+	v.VMACIntf = strings.TrimSpace(args[0])
+	v.BaseIntf = strings.TrimSpace(base)
+}
+
+func (v *VRRPData) setMAC(macs map[string]string) {
+	if v.VMACIntf == "" {
+		return
+	}
+
+	v.MAC = macs[v.VMACIntf]
+}
+
+func (v *VRRPData) setTrackedInterfacesStatus(statuses map[string]string) {
+	for i, intf := range v.TrackedInterfaces {
+		if status, ok := statuses[intf.Name]; ok {
+			v.TrackedInterfaces[i].Status = status
+		}
+	}
+}
+
+// trackedScripts returns scripts tracked by the instance, falling back to the
+// tracking instances of scripts when the instance section does not list them.
+func (v *VRRPData) trackedScripts(scripts []VRRPScript) []VRRPTrackedScript {
+	if len(v.TrackedScripts) > 0 {
+		return v.TrackedScripts
+	}
+
+	trackedScripts := make([]VRRPTrackedScript, 0)
+
+	for _, script := range scripts {
+		for _, instance := range script.Instances {
+			if instance.IName == v.IName {
+				trackedScripts = append(trackedScripts, VRRPTrackedScript{Name: script.Name, Weight: instance.Weight})
+			}
+		}
+	}
+
+	return trackedScripts
+}
+
+func (v *VRRPScript) addInstance(instance string) {
+	// value is in "VI_1, weight 100" format
+	args := strings.Split(instance, ", weight ")
+
+	scriptInstance := VRRPScriptInstance{IName: strings.TrimSpace(args[0])}
+	if scriptInstance.IName == "" {
+		return
+	}
+
+	if len(args) == 2 {
+		var err error
+		if scriptInstance.Weight, err = strconv.Atoi(strings.TrimSpace(args[1])); err != nil {
+			slog.Warn("Failed to parse script tracking instance weight to int",
+				"instance", instance,
+				"name", v.Name,
+				"error", err,
+			)
+		}
+	}
+
+	v.Instances = append(v.Instances, scriptInstance)
+}
+
+func (g *VRRPSyncGroup) setState(state string) error {
+	var ok bool
+	if g.State, ok = vrrpDataStringToIntState(state); !ok {
+		slog.Error("Unknown sync group state found",
+			"state", state,
+			"group", g.Name,
+		)
+
+		return fmt.Errorf("unknown sync group state found: %s, group: %s", state, g.Name)
+	}
+
+	return nil
+}
+
+func (g *VRRPSyncGroup) addNotifyScript(key, script string) {
+	// key is in "Master state transition script" format and
+	// value is in "'/etc/keepalived/notify.sh master', uid:gid 0:0" format
+	transition := strings.ToLower(strings.TrimSpace(strings.TrimSuffix(key, "state transition script")))
+	script, _, _ = strings.Cut(script, ", uid:gid")
+
+	g.NotifyScripts[transition] = script
+}
+
+func (v *VRRPScript) parseInt(key, value string) int {
+	// value may have a suffix like "100 reverse"
+	args := strings.Fields(value)
+	if len(args) == 0 {
+		return 0
+	}
+
+	i, err := strconv.Atoi(args[0])
+	if err != nil {
+		slog.Warn("Failed to parse script value to int",
+			"key", key,
+			"value", value,
+			"name", v.Name,
+			"error", err,
+		)
+	}
+
+	return i
+}
+
+func (v *VRRPScript) parseSeconds(key, value string) float64 {
+	seconds, err := parseSeconds(value)
+	if err != nil {
+		slog.Warn("Failed to parse script value to seconds",
+			"key", key,
+			"value", value,
+			"name", v.Name,
+			"error", err,
+		)
+	}
+
+	return seconds
+}
+
+func (g *GlobalDefinitions) setStartupTime(startupTime string) error {
+	var err error
+	if g.StartupTime, err = parseTimestamp(startupTime); err != nil {
+		slog.Error("Failed to parse startup time to float",
+			"startupTime", startupTime,
+			"routerID", g.RouterID,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (g *GlobalDefinitions) setConfigLoadTime(configLoadTime string) error {
+	var err error
+	if g.ConfigLoadTime, err = parseTimestamp(configLoadTime); err != nil {
+		slog.Error("Failed to parse config load time to float",
+			"configLoadTime", configLoadTime,
+			"routerID", g.RouterID,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (vs *VirtualServer) setQuorum(quorum string) error {
+	var err error
+	if vs.Quorum, err = strconv.Atoi(quorum); err != nil {
+		slog.Error("Failed to parse quorum to int",
+			"quorum", quorum,
+			"vs", vs.Name,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (rs *RealServer) setWeight(weight string) error {
+	var err error
+	if rs.Weight, err = strconv.Atoi(weight); err != nil {
+		slog.Error("Failed to parse weight to int",
+			"weight", weight,
+			"rs", rs.Name,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+// addChecker attaches checker to the real server of rsName and reports whether it's found.
+func (vs *VirtualServer) addChecker(rsName string, checker RealServerChecker) bool {
+	for i := range vs.RealServers {
+		if vs.RealServers[i].Name == rsName {
+			vs.RealServers[i].Checkers = append(vs.RealServers[i].Checkers, checker)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *VRRPTrackFile) setValue(value string) error {
+	var err error
+	if f.Value, err = strconv.Atoi(value); err != nil {
+		slog.Error("Failed to parse track file status to int",
+			"status", value,
+			"name", f.Name,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (f *VRRPTrackFile) setWeight(weight string) error {
+	var err error
+	// value may have a suffix like "10 reverse"
+	if f.Weight, err = strconv.Atoi(strings.Fields(weight + " ")[0]); err != nil {
+		slog.Error("Failed to parse track file weight to int",
+			"weight", weight,
+			"name", f.Name,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *VRRPTrackProcess) setCount(count string) error {
+	var err error
+	if p.Count, err = strconv.Atoi(count); err != nil {
+		slog.Error("Failed to parse track process count to int",
+			"count", count,
+			"name", p.Name,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (p *VRRPTrackProcess) setWeight(weight string) error {
+	var err error
+	// value may have a suffix like "10 reverse"
+	if p.Weight, err = strconv.Atoi(strings.Fields(weight + " ")[0]); err != nil {
+		slog.Error("Failed to parse track process weight to int",
+			"weight", weight,
+			"name", p.Name,
+		)
+
+		return err
+	}
+
+	return nil
+}
+
+func (v *VRRPData) addTrackedBFD(bfd string) error {
+	// value is in "bfd_name: weight 10" format
+	args := strings.Fields(bfd)
+	if len(args) == 0 {
+		return nil
+	}
+
+	trackedBFD := VRRPTrackedBFD{Name: strings.TrimSuffix(args[0], ":")}
+
+	if len(args) >= 3 && args[1] == "weight" {
+		var err error
+		if trackedBFD.Weight, err = strconv.Atoi(args[2]); err != nil {
+			slog.Error("Failed to parse tracked BFD weight to int",
+				"bfd", bfd,
+				"iname", v.IName,
+			)
+
+			return err
+		}
+	}
+
+	v.TrackedBFDs = append(v.TrackedBFDs, trackedBFD)
+
+	return nil
+}
+
+func (b *BFDInstance) setLocalState(state string) error {
+	var ok bool
+	if b.LocalState, ok = bfdStringToIntState(state); !ok {
+		slog.Error("Unknown BFD local state found",
+			"state", state,
+			"name", b.Name,
+		)
+
+		return fmt.Errorf("unknown BFD local state found: %s, name: %s", state, b.Name)
+	}
+
+	return nil
+}
+
+func (b *BFDInstance) setRemoteState(state string) error {
+	var ok bool
+	if b.RemoteState, ok = bfdStringToIntState(state); !ok {
+		slog.Error("Unknown BFD remote state found",
+			"state", state,
+			"name", b.Name,
+		)
+
+		return fmt.Errorf("unknown BFD remote state found: %s, name: %s", state, b.Name)
+	}
+
+	return nil
+}
+
+func (b *BFDInstance) setDiscriminator(discriminator *uint32, value string) error {
+	// value is in hex like "0x1a2b3c4d"
+	d, err := strconv.ParseUint(value, 0, 32)
+	if err != nil {
+		slog.Error("Failed to parse BFD discriminator",
+			"discriminator", value,
+			"name", b.Name,
+		)
+
+		return err
+	}
+
+	*discriminator = uint32(d)
+
+	return nil
+}
+
+func (b *BFDInstance) setTransitions(transitions string) error {
+	var err error
+	if b.Transitions, err = strconv.Atoi(transitions); err != nil {
+		slog.Error("Failed to parse BFD state transitions to int",
+			"transitions", transitions,
+			"name", b.Name,
+		)
+
+		return err
+	}
+
+	return nil
 }
@@ -95,13 +95,136 @@ func TestSetVRID(t *testing.T) {
 	}
 }
 
+func TestSetPriority(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		priority string
+		expected int
+		err      error
+	}{
+		{priority: "100", expected: 100, err: nil},
+		{priority: "1.1", expected: 0, err: strconv.ErrSyntax},
+		{priority: "NA", expected: 0, err: strconv.ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.priority, func(t *testing.T) {
+			t.Parallel()
+
+			data := VRRPData{}
+			if err := data.setPriority(tc.priority); !errors.Is(err, tc.err) || data.Priority != tc.expected {
+				t.Fail()
+			}
+
+			if err := data.setEffectivePriority(tc.priority); !errors.Is(err, tc.err) || data.EffectivePriority != tc.expected {
+				t.Fail()
+			}
+
+			if err := data.setTotalPriority(tc.priority); !errors.Is(err, tc.err) || data.TotalPriority != tc.expected {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestSetFaults(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		faults   string
+		expected int
+		err      error
+	}{
+		{faults: "2", expected: 2, err: nil},
+		{faults: "1.1", expected: 0, err: strconv.ErrSyntax},
+		{faults: "NA", expected: 0, err: strconv.ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.faults, func(t *testing.T) {
+			t.Parallel()
+
+			data := VRRPData{}
+			if err := data.setConfigFaults(tc.faults); !errors.Is(err, tc.err) || data.ConfigFaults != tc.expected {
+				t.Fail()
+			}
+
+			if err := data.setTrackFaults(tc.faults); !errors.Is(err, tc.err) || data.TrackFaults != tc.expected {
+				t.Fail()
+			}
+
+			if err := data.setTrackScriptsInit(tc.faults); !errors.Is(err, tc.err) || data.TrackScriptsInit != tc.expected {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestSetTimers(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{}
+
+	data.setAdvertInterval("4000 milli-sec")
+	data.setMasterDownTimer("1656250 usecs")
+	data.setDownTimerAdverts("3")
+	data.setReadTimeout("1700654413.713213 (Wed Nov 22 12:00:13.713213 2023)")
+
+	if data.AdvertInterval != 4 || data.MasterDownTimer != 1.65625 || data.DownTimerAdverts != 3 ||
+		data.ReadTimeout != 1700654413.713213 {
+		t.Fail()
+	}
+
+	// unknown values are skipped instead of failing the parse
+	data.setAdvertInterval("2 centi-sec")
+	data.setMasterDownTimer("1656250")
+	data.setDownTimerAdverts("NA")
+	data.setReadTimeout("")
+
+	if data.AdvertInterval != 0 || data.MasterDownTimer != 0 || data.DownTimerAdverts != 0 || data.ReadTimeout != 0 {
+		t.Fail()
+	}
+}
+
+func TestSetLastTransition(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		lastTransition string
+		expected       float64
+		ok             bool
+	}{
+		{lastTransition: "1673674892.348360 (Sat Jan 14 06:41:32.348360 2023)", expected: 1673674892.348360, ok: true},
+		{lastTransition: "1596892296 (Sat Aug  8 13:11:36 2020)", expected: 1596892296, ok: true},
+		{lastTransition: "NA", expected: 0, ok: false},
+		{lastTransition: "", expected: 0, ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.lastTransition, func(t *testing.T) {
+			t.Parallel()
+
+			data := VRRPData{}
+			if err := data.setLastTransition(tc.lastTransition); (err == nil) != tc.ok || data.LastTransition != tc.expected {
+				t.Fail()
+			}
+		})
+	}
+}
+
 func TestAddVIP(t *testing.T) {
 	t.Parallel()
 
 	data := VRRPData{}
 
-	vips := []string{"   1.1.1.1", "2.2.2.2", "3.3.3.3   "}
-	expectedVIPs := []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}
+	vips := []string{"   1.1.1.1", "2.2.2.2/24 dev eth0", "3.3.3.3   ", "bad"}
+	expectedVIPs := []VIP{
+		{Raw: "1.1.1.1", Address: "1.1.1.1", Prefix: 32, Family: "ipv4"},
+		{Raw: "2.2.2.2/24", Address: "2.2.2.2", Prefix: 24, Family: "ipv4", Device: "eth0"},
+		{Raw: "3.3.3.3", Address: "3.3.3.3", Prefix: 32, Family: "ipv4"},
+		{Raw: "bad"},
+	}
 
 	for _, vip := range vips {
 		data.addVIP(vip)
@@ -117,8 +240,13 @@ func TestAddExcludedVIP(t *testing.T) {
 
 	data := VRRPData{}
 
-	vips := []string{"   1.1.1.1", "2.2.2.2", "3.3.3.3   "}
-	expectedVIPs := []string{"1.1.1.1", "2.2.2.2", "3.3.3.3"}
+	vips := []string{"   1.1.1.1", "fd00::1 dev eth0", "3.3.3.3   ", "bad"}
+	expectedVIPs := []VIP{
+		{Raw: "1.1.1.1", Address: "1.1.1.1", Prefix: 32, Family: "ipv4"},
+		{Raw: "fd00::1", Address: "fd00::1", Prefix: 128, Family: "ipv6", Device: "eth0"},
+		{Raw: "3.3.3.3", Address: "3.3.3.3", Prefix: 32, Family: "ipv4"},
+		{Raw: "bad"},
+	}
 
 	for _, vip := range vips {
 		data.addExcludedVIP(vip)
@@ -128,3 +256,283 @@ func TestAddExcludedVIP(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestAddTrackedScript(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{}
+
+	for _, script := range []string{"chk_script weight 100", "chk_service weight -60", "chk_noweight"} {
+		if err := data.addTrackedScript(script); err != nil {
+			t.Fail()
+		}
+	}
+
+	expected := []VRRPTrackedScript{
+		{Name: "chk_script", Weight: 100},
+		{Name: "chk_service", Weight: -60},
+		{Name: "chk_noweight", Weight: 0},
+	}
+	if !reflect.DeepEqual(expected, data.TrackedScripts) {
+		t.Fail()
+	}
+
+	if err := data.addTrackedScript("chk_script weight NA"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fail()
+	}
+}
+
+func TestAddTrackedInterface(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{}
+
+	for _, intf := range []string{"eth1 weight 50", "eth2 weight -20 reverse", "eth3"} {
+		if err := data.addTrackedInterface(intf); err != nil {
+			t.Fail()
+		}
+	}
+
+	data.setTrackedInterfacesStatus(map[string]string{"eth1": "UP", "eth2": "DOWN"})
+
+	expected := []VRRPTrackedInterface{
+		{Name: "eth1", Weight: 50, Status: "UP"},
+		{Name: "eth2", Weight: -20, Status: "DOWN"},
+		{Name: "eth3", Weight: 0, Status: ""},
+	}
+	if !reflect.DeepEqual(expected, data.TrackedInterfaces) {
+		t.Fail()
+	}
+
+	if err := data.addTrackedInterface("eth1 weight NA"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fail()
+	}
+}
+
+func TestTrackedScripts(t *testing.T) {
+	t.Parallel()
+
+	scripts := []VRRPScript{
+		{Name: "chk_script", Instances: []VRRPScriptInstance{{IName: "VI_1", Weight: 10}, {IName: "VI_2", Weight: 20}}},
+		{Name: "chk_other", Instances: []VRRPScriptInstance{{IName: "VI_2", Weight: 30}}},
+	}
+
+	data := VRRPData{IName: "VI_1"}
+	if !reflect.DeepEqual(data.trackedScripts(scripts), []VRRPTrackedScript{{Name: "chk_script", Weight: 10}}) {
+		t.Fail()
+	}
+
+	data.TrackedScripts = []VRRPTrackedScript{{Name: "chk_own", Weight: 5}}
+	if !reflect.DeepEqual(data.trackedScripts(scripts), data.TrackedScripts) {
+		t.Fail()
+	}
+}
+
+func TestAddInstance(t *testing.T) {
+	t.Parallel()
+
+	script := VRRPScript{}
+	script.addInstance("VI_1, weight -60")
+	script.addInstance("VI_2")
+	script.addInstance("")
+
+	expected := []VRRPScriptInstance{{IName: "VI_1", Weight: -60}, {IName: "VI_2", Weight: 0}}
+	if !reflect.DeepEqual(expected, script.Instances) {
+		t.Fail()
+	}
+}
+
+func TestSyncGroupSetState(t *testing.T) {
+	t.Parallel()
+
+	group := VRRPSyncGroup{}
+
+	for expected, state := range VRRPStates {
+		if err := group.setState(state); err != nil || group.State != expected {
+			t.Fail()
+		}
+	}
+
+	if err := group.setState("NOGOOD"); err == nil || group.State != -1 {
+		t.Fail()
+	}
+}
+
+func TestAddNotifyScript(t *testing.T) {
+	t.Parallel()
+
+	group := VRRPSyncGroup{NotifyScripts: make(map[string]string)}
+	group.addNotifyScript("Master state transition script", "'/etc/keepalived/notify.sh master', uid:gid 0:0")
+	group.addNotifyScript("Generic state transition script", "'/etc/keepalived/notify.sh'")
+
+	expected := map[string]string{
+		"master":  "'/etc/keepalived/notify.sh master'",
+		"generic": "'/etc/keepalived/notify.sh'",
+	}
+	if !reflect.DeepEqual(expected, group.NotifyScripts) {
+		t.Fail()
+	}
+}
+
+func TestScriptParseValues(t *testing.T) {
+	t.Parallel()
+
+	script := VRRPScript{Name: "chk_script"}
+
+	if script.parseInt("Weight", "-60") != -60 || script.parseInt("Weight", "100 reverse") != 100 {
+		t.Fail()
+	}
+
+	if script.parseInt("Rise", "NA") != 0 || script.parseInt("Rise", "") != 0 {
+		t.Fail()
+	}
+
+	if script.parseSeconds("Interval", "2 sec") != 2 || script.parseSeconds("Interval", "NA") != 0 {
+		t.Fail()
+	}
+}
+
+func TestSetGlobalTimes(t *testing.T) {
+	t.Parallel()
+
+	global := GlobalDefinitions{}
+
+	if err := global.setStartupTime("1700568010.102443 (Tue Nov 21 12:00:10.102443 2023)"); err != nil || global.StartupTime != 1700568010.102443 {
+		t.Fail()
+	}
+
+	if err := global.setConfigLoadTime("1700568012 (Tue Nov 21 12:00:12 2023)"); err != nil || global.ConfigLoadTime != 1700568012 {
+		t.Fail()
+	}
+
+	if err := global.setConfigLoadTime("NA"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fail()
+	}
+}
+
+func TestCheckerSetters(t *testing.T) {
+	t.Parallel()
+
+	vs := VirtualServer{Name: "[10.0.0.100]:tcp:80", RealServers: []RealServer{{Name: "[192.168.1.11]:tcp:80"}}}
+
+	if err := vs.setQuorum("2"); err != nil || vs.Quorum != 2 {
+		t.Fail()
+	}
+
+	if err := vs.setQuorum("NA"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fail()
+	}
+
+	if err := vs.RealServers[0].setWeight("3"); err != nil || vs.RealServers[0].Weight != 3 {
+		t.Fail()
+	}
+
+	if err := vs.RealServers[0].setWeight("NA"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Fail()
+	}
+
+	if !vs.addChecker("[192.168.1.11]:tcp:80", RealServerChecker{Type: "HTTP_GET", Up: true}) {
+		t.Fail()
+	}
+
+	if vs.addChecker("[192.168.1.99]:tcp:80", RealServerChecker{Type: "TCP_CHECK"}) {
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(vs.RealServers[0].Checkers, []RealServerChecker{{Type: "HTTP_GET", Up: true}}) {
+		t.Fail()
+	}
+}
+
+func TestSetTransport(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{}
+
+	if data.transportMode() != "" {
+		t.Fail()
+	}
+
+	data.setSrcIP("192.168.1.1 (from configuration)")
+	data.McastGroup = "224.0.0.18"
+
+	if data.SrcIP != "192.168.1.1" || data.transportMode() != "multicast" {
+		t.Fail()
+	}
+
+	data.addUnicastPeer("192.168.1.2 min_ttl 0 max_ttl 255")
+	data.addUnicastPeer("fd00::12")
+
+	if !reflect.DeepEqual(data.UnicastPeers, []string{"192.168.1.2", "fd00::12"}) || data.transportMode() != "unicast" {
+		t.Fail()
+	}
+}
+
+func TestSetIntf(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		intf     string
+		expected [3]string
+	}{
+		{intf: "eth0", expected: [3]string{"eth0", "", ""}},
+		{intf: "vrrp.61, vmac on eth0, xmit base i/f", expected: [3]string{"vrrp.61, vmac on eth0, xmit base i/f", "eth0", "vrrp.61"}},
+		{intf: "vrrp.62, ipvlan on eth1", expected: [3]string{"vrrp.62, ipvlan on eth1", "eth1", "vrrp.62"}},
+		{intf: "eth0, unknown", expected: [3]string{"eth0, unknown", "", ""}},
+	}
+
+	for _, tc := range testCases {
+		data := VRRPData{}
+		data.setIntf(tc.intf)
+
+		if [3]string{data.Intf, data.BaseIntf, data.VMACIntf} != tc.expected {
+			t.Log(tc.intf)
+			t.Fail()
+		}
+	}
+
+	data := VRRPData{VMACIntf: "vrrp.61"}
+	data.setMAC(map[string]string{"vrrp.61": "00:00:5e:00:01:3d"})
+
+	if data.MAC != "00:00:5e:00:01:3d" {
+		t.Fail()
+	}
+}
+
+func TestAddVirtualRoutesAndRules(t *testing.T) {
+	t.Parallel()
+
+	data := VRRPData{}
+
+	data.addVirtualRoute("192.168.100.0/24 via 10.0.0.1 dev eth0 table 100")
+	data.addVirtualRoute("default via 10.0.0.1 dev eth0")
+	data.addVirtualRoute("blackhole 10.10.0.1 table local")
+	data.addVirtualRoute("fd00:1::/64 dev eth0 table custom")
+	data.addVirtualRoute("10.20.0.0/33 dev eth0")
+	data.addVirtualRoute("default via inet6 fd00::1 dev eth0 metric 1024")
+	data.addVirtualRoute("default via 10.0.0.300 dev eth0")
+	data.addVirtualRoute("10.30.0.0/16 dev eth0 metric high")
+
+	expectedRoutes := []VirtualRoute{
+		{Dst: "192.168.100.0/24", Family: "ipv4", Via: "10.0.0.1", Table: 100},
+		{Dst: "default", Family: "ipv4", Via: "10.0.0.1", Table: 254},
+		{Dst: "10.10.0.1/32", Family: "ipv4", Table: 255},
+		{Dst: "fd00:1::/64", Family: "ipv6", Table: 0},
+		{Dst: "default", Family: "ipv6", Via: "fd00::1", Metric: 1024, Table: 254},
+	}
+	if !reflect.DeepEqual(data.VirtualRoutes, expectedRoutes) {
+		t.Fail()
+	}
+
+	data.addVirtualRule("from 10.0.0.100 table 100")
+	data.addVirtualRule("from all to 10.1.0.0/16 lookup main")
+	data.addVirtualRule("from 10.0.0.300 table 100")
+
+	expectedRules := []VirtualRule{
+		{From: "10.0.0.100/32", To: "all", Table: 100},
+		{From: "all", To: "10.1.0.0/16", Table: 254},
+	}
+	if !reflect.DeepEqual(data.VirtualRules, expectedRules) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,106 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// snapshot is the result of one keepalived refresh. It's never modified once built.
+type snapshot struct {
+	// stats is nil when keepalived data could not be read
+	stats         *KeepalivedStats
+	addresses     map[string][]string
+	routingTables *RoutingTables
+	time          time.Time
+	duration      time.Duration
+}
+
+// refresh signals keepalived and reads its data and the kernel state into a new snapshot.
+func (k *KeepalivedCollector) refresh() *snapshot {
+	start := time.Now()
+	s := &snapshot{}
+
+	var err error
+	if s.stats, err = k.getKeepalivedStats(); err != nil {
+		// previous data is never exported when keepalived did not rewrite its dump in time
+		if errors.Is(err, ErrStaleDump) {
+			k.staleDumps.Add(1)
+		}
+
+		slog.Error("No data found to be exported", "error", err)
+	}
+
+	if s.stats != nil && k.options.VerifyVIPs {
+		var err error
+		if s.addresses, err = k.collector.InterfaceAddresses(); err != nil {
+			slog.Error("Failed to read interface addresses, skipping VIP verification", "error", err)
+		}
+	}
+
+	if s.stats != nil && k.options.VerifyRoutes {
+		var err error
+		if s.routingTables, err = k.collector.RoutingTables(); err != nil {
+			slog.Error("Failed to read routing tables, skipping virtual routes verification", "error", err)
+		}
+	}
+
+	s.time = time.Now()
+	s.duration = s.time.Sub(start)
+
+	return s
+}
+
+// currentSnapshot returns the latest snapshot when polling, otherwise it refreshes keepalived.
+// Concurrent callers share the snapshot of a single in-flight refresh.
+func (k *KeepalivedCollector) currentSnapshot() *snapshot {
+	k.Lock()
+	s, polling := k.snapshot, k.polling
+	k.Unlock()
+
+	if polling {
+		return s
+	}
+
+	v, _, _ := k.refreshGroup.Do("refresh", func() (any, error) {
+		s := k.refresh()
+
+		k.Lock()
+		k.snapshot = s
+		k.Unlock()
+
+		return s, nil
+	})
+
+	return v.(*snapshot)
+}
+
+// StartPolling refreshes keepalived data every interval in background until ctx is done.
+// Collect then exports the latest snapshot instead of signalling keepalived on every scrape.
+func (k *KeepalivedCollector) StartPolling(ctx context.Context, interval time.Duration) {
+	s := k.refresh()
+
+	k.Lock()
+	k.snapshot = s
+	k.polling = true
+	k.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s := k.refresh()
+
+				k.Lock()
+				k.snapshot = s
+				k.Unlock()
+			}
+		}
+	}()
+}
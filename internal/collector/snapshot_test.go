@@ -0,0 +1,228 @@
+package collector
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// collectGauges returns the value of gauges without labels exported by a single Collect.
+func collectGauges(t *testing.T, k *KeepalivedCollector) map[string]float64 {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric, 100)
+	k.Collect(ch)
+	close(ch)
+
+	gauges := make(map[string]float64)
+
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, desc := range k.metrics {
+			if desc == m.Desc() && len(metric.GetLabel()) == 0 {
+				gauges[name] = metric.GetGauge().GetValue()
+			}
+		}
+	}
+
+	return gauges
+}
+
+func TestCollectRefreshesOnScrape(t *testing.T) {
+	t.Parallel()
+
+	c := &testCollector{vrrps: testVRRPs}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+
+	for range 3 {
+		if collectGauges(t, k)["keepalived_up"] != 1 {
+			t.Fail()
+		}
+	}
+
+	if c.refreshes.Load() != 3 {
+		t.Fail()
+	}
+}
+
+func TestStartPolling(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &testCollector{vrrps: testVRRPs, delay: 10 * time.Millisecond}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+	k.StartPolling(ctx, time.Hour)
+
+	time.Sleep(20 * time.Millisecond)
+
+	for range 3 {
+		gauges := collectGauges(t, k)
+		if gauges["keepalived_up"] != 1 {
+			t.Fail()
+		}
+
+		if gauges["keepalived_exporter_snapshot_age_seconds"] < 0.02 {
+			t.Fail()
+		}
+
+		if gauges["keepalived_exporter_refresh_duration_seconds"] < 0.01 {
+			t.Fail()
+		}
+	}
+
+	// scrapes are served from the snapshot taken when polling started
+	if c.refreshes.Load() != 1 {
+		t.Fail()
+	}
+}
+
+func TestStartPollingRefreshes(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &testCollector{vrrps: testVRRPs}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+	k.StartPolling(ctx, 5*time.Millisecond)
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if c.refreshes.Load() < 3 {
+		t.Fail()
+	}
+}
+
+func TestConcurrentCollectsShareRefresh(t *testing.T) {
+	t.Parallel()
+
+	const (
+		scrapes = 5
+		delay   = 100 * time.Millisecond
+	)
+
+	c := &testCollector{vrrps: testVRRPs, delay: delay}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for range scrapes {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if collectGauges(t, k)["keepalived_up"] != 1 {
+				t.Fail()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// scrapes queued behind each other would take scrapes*delay
+	if elapsed := time.Since(start); elapsed >= 2*delay {
+		t.Log(elapsed)
+		t.Fail()
+	}
+
+	if c.refreshes.Load() != 1 {
+		t.Log(c.refreshes.Load())
+		t.Fail()
+	}
+}
+
+func TestSlowCollectDoesNotBlockPolling(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := &testCollector{vrrps: testVRRPs}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+	k.StartPolling(ctx, time.Hour)
+
+	// a slow consumer of Collect must not hold the lock guarding snapshot replacement
+	ch := make(chan prometheus.Metric)
+	done := make(chan struct{})
+
+	go func() {
+		k.Collect(ch)
+		close(done)
+	}()
+
+	<-ch
+
+	replaced := make(chan struct{})
+
+	go func() {
+		s := k.refresh()
+
+		k.Lock()
+		k.snapshot = s
+		k.Unlock()
+
+		close(replaced)
+	}()
+
+	select {
+	case <-replaced:
+	case <-time.After(time.Second):
+		t.Fail()
+	}
+
+	for {
+		select {
+		case <-ch:
+		case <-done:
+			return
+		}
+	}
+}
+
+func TestCollectStaleDump(t *testing.T) {
+	t.Parallel()
+
+	c := &testCollector{vrrps: testVRRPs, refreshErr: ErrStaleDump}
+	k := NewKeepalivedCollector(Options{JSON: true}, c)
+
+	collectGauges(t, k)
+
+	ch := make(chan prometheus.Metric, 100)
+	k.Collect(ch)
+	close(ch)
+
+	var staleDumps float64
+
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatal(err)
+		}
+
+		switch m.Desc() {
+		case k.metrics["keepalived_exporter_stale_dump_total"]:
+			staleDumps = metric.GetCounter().GetValue()
+		case k.metrics["keepalived_up"]:
+			if metric.GetGauge().GetValue() != 0 {
+				t.Fail()
+			}
+		}
+	}
+
+	if staleDumps != 2 {
+		t.Fail()
+	}
+}
@@ -2,6 +2,7 @@ package container
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -21,7 +22,10 @@ type KeepalivedContainerCollectorHost struct {
 	version       *version.Version
 	useJSON       bool
 	containerName string
+	tmpDir        string
 	dataPath      string
+	checkPath     string
+	bfdPath       string
 	jsonPath      string
 	statsPath     string
 	dockerCli     *client.Client
@@ -63,36 +67,48 @@ func NewKeepalivedContainerCollectorHost(
 	return k
 }
 
-func (k *KeepalivedContainerCollectorHost) Refresh() error {
+func (k *KeepalivedContainerCollectorHost) Refresh(dumps ...string) error {
 	if k.useJSON {
-		if err := k.signal(k.SIGJSON); err != nil {
-			slog.Error("Failed to send JSON signal to keepalived", "error", err)
+		return collector.WaitForDumps([]string{k.jsonPath}, collector.DumpTimeout, func() error {
+			if err := k.signal(k.SIGJSON); err != nil {
+				slog.Error("Failed to send JSON signal to keepalived", "error", err)
 
-			return err
-		}
+				return err
+			}
 
-		return nil
+			return nil
+		})
 	}
 
-	if err := k.signal(k.SIGSTATS); err != nil {
-		slog.Error("Failed to send STATS signal to keepalived", "error", err)
-
-		return err
+	paths := []string{k.statsPath, k.dataPath}
+	for _, dump := range dumps {
+		paths = append(paths, filepath.Join(k.tmpDir, dump))
 	}
 
-	if err := k.signal(k.SIGDATA); err != nil {
-		slog.Error("Failed to send DATA signal to keepalived", "error", err)
+	return collector.WaitForDumps(paths, collector.DumpTimeout, func() error {
+		if err := k.signal(k.SIGSTATS); err != nil {
+			slog.Error("Failed to send STATS signal to keepalived", "error", err)
 
-		return err
-	}
+			return err
+		}
 
-	return nil
+		if err := k.signal(k.SIGDATA); err != nil {
+			slog.Error("Failed to send DATA signal to keepalived", "error", err)
+
+			return err
+		}
+
+		return nil
+	})
 }
 
 func (k *KeepalivedContainerCollectorHost) initPaths(containerTmpDir string) {
+	k.tmpDir = containerTmpDir
 	k.jsonPath = filepath.Join(containerTmpDir, "keepalived.json")
 	k.statsPath = filepath.Join(containerTmpDir, "keepalived.stats")
 	k.dataPath = filepath.Join(containerTmpDir, "keepalived.data")
+	k.checkPath = filepath.Join(containerTmpDir, "keepalived_check.data")
+	k.bfdPath = filepath.Join(containerTmpDir, "keepalived_bfd.data")
 }
 
 // GetKeepalivedVersion returns Keepalived version.
@@ -196,98 +212,93 @@ func (k *KeepalivedContainerCollectorHost) signal(signal syscall.Signal) error {
 
 // JSONVrrps send SIGJSON and parse the data to the list of collector.VRRP struct.
 func (k *KeepalivedContainerCollectorHost) JSONVrrps() ([]collector.VRRP, error) {
-	f, err := os.Open(k.jsonPath)
-	if err != nil {
-		slog.Error("Failed to open keepalived.json",
-			"error", err,
-			"path", k.jsonPath,
-		)
-
-		return nil, err
-	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close keepalived.json file",
-				"error", err,
-				"path", k.jsonPath,
-			)
-		}
-	}()
-
-	return collector.ParseJSON(f)
+	return collector.ParseFile(k.jsonPath, collector.ParseJSON)
 }
 
 // StatsVrrps send SIGSTATS and parse the stats.
 func (k *KeepalivedContainerCollectorHost) StatsVrrps() (map[string]*collector.VRRPStats, error) {
-	f, err := os.Open(k.statsPath)
-	if err != nil {
-		slog.Error("Failed to open keepalived.stats",
-			"error", err,
-			"path", k.statsPath,
-		)
+	return collector.ParseFile(k.statsPath, collector.ParseStats)
+}
+
+// DataVrrps send SIGDATA ans parse the data.
+func (k *KeepalivedContainerCollectorHost) DataVrrps() (map[string]*collector.VRRPData, error) {
+	return collector.ParseFile(k.dataPath, collector.ParseVRRPData)
+}
 
+// ScriptVrrps parse the script data from keepalived.data.
+func (k *KeepalivedContainerCollectorHost) ScriptVrrps() ([]collector.VRRPScript, error) {
+	return collector.ParseFile(k.dataPath, func(r io.Reader) ([]collector.VRRPScript, error) {
+		return collector.ParseVRRPScript(r), nil
+	})
+}
+
+// SyncGroupVrrps parse the sync group data from keepalived.data.
+func (k *KeepalivedContainerCollectorHost) SyncGroupVrrps() ([]collector.VRRPSyncGroup, error) {
+	return collector.ParseFile(k.dataPath, collector.ParseVRRPSyncGroups)
+}
+
+// TrackFileVrrps parse the track files from keepalived.data.
+func (k *KeepalivedContainerCollectorHost) TrackFileVrrps() ([]collector.VRRPTrackFile, error) {
+	return collector.ParseFile(k.dataPath, collector.ParseVRRPTrackFiles)
+}
+
+// TrackProcessVrrps parse the track processes from keepalived.data.
+func (k *KeepalivedContainerCollectorHost) TrackProcessVrrps() ([]collector.VRRPTrackProcess, error) {
+	return collector.ParseFile(k.dataPath, collector.ParseVRRPTrackProcesses)
+}
+
+// GlobalDefinitions parse the global definitions from keepalived.data.
+func (k *KeepalivedContainerCollectorHost) GlobalDefinitions() (*collector.GlobalDefinitions, error) {
+	return collector.ParseFile(k.dataPath, collector.ParseGlobalDefinitions)
+}
+
+// CheckerVirtualServers parse the LVS virtual servers from keepalived_check.data.
+func (k *KeepalivedContainerCollectorHost) CheckerVirtualServers() ([]collector.VirtualServer, error) {
+	return collector.ParseFile(k.checkPath, collector.ParseCheckerData)
+}
+
+// BFDInstances parse the BFD instances from keepalived_bfd.data.
+func (k *KeepalivedContainerCollectorHost) BFDInstances() ([]collector.BFDInstance, error) {
+	return collector.ParseFile(k.bfdPath, collector.ParseBFDData)
+}
+
+// IPVSServices reads the IPVS tables from network namespace of Keepalived container.
+func (k *KeepalivedContainerCollectorHost) IPVSServices() ([]collector.IPVSService, error) {
+	procPath, err := k.containerProcPath()
+	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close keepalived.stats file",
-				"error", err,
-				"path", k.statsPath,
-			)
-		}
-	}()
 
-	return collector.ParseStats(f)
+	return collector.ReadIPVS(filepath.Join(procPath, "ns", "net"), filepath.Join(procPath, "net", "ip_vs"))
 }
 
-// DataVrrps send SIGDATA ans parse the data.
-func (k *KeepalivedContainerCollectorHost) DataVrrps() (map[string]*collector.VRRPData, error) {
-	f, err := os.Open(k.dataPath)
+// InterfaceAddresses reads the kernel addresses from network namespace of Keepalived container.
+func (k *KeepalivedContainerCollectorHost) InterfaceAddresses() (map[string][]string, error) {
+	procPath, err := k.containerProcPath()
 	if err != nil {
-		slog.Error("Failed to open keepalived.data",
-			"error", err,
-			"path", k.dataPath,
-		)
-
 		return nil, err
 	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close keepalived.data file",
-				"error", err,
-				"path", k.dataPath,
-			)
-		}
-	}()
 
-	return collector.ParseVRRPData(f)
+	return collector.ReadInterfaceAddresses(filepath.Join(procPath, "ns", "net"))
 }
 
-// ScriptVrrps parse the script data from keepalived.data.
-func (k *KeepalivedContainerCollectorHost) ScriptVrrps() ([]collector.VRRPScript, error) {
-	f, err := os.Open(k.dataPath)
+// RoutingTables reads the kernel routes and rules from network namespace of Keepalived container.
+func (k *KeepalivedContainerCollectorHost) RoutingTables() (*collector.RoutingTables, error) {
+	procPath, err := k.containerProcPath()
 	if err != nil {
-		slog.Error("Failed to open keepalived.data",
-			"error", err,
-			"path", k.dataPath,
-		)
-
 		return nil, err
 	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close keepalived.data file",
-				"error", err,
-				"path", k.dataPath,
-			)
-		}
-	}()
 
-	return collector.ParseVRRPScript(f), nil
+	return collector.ReadRoutingTables(filepath.Join(procPath, "ns", "net"))
+}
+
+// KeepalivedVersion returns detected Keepalived version or empty string when detection failed.
+func (k *KeepalivedContainerCollectorHost) KeepalivedVersion() string {
+	if k.version == nil {
+		return ""
+	}
+
+	return k.version.String()
 }
 
 // HasVRRPScriptStateSupport check if Keepalived version supports VRRP Script State in output.
@@ -12,6 +12,10 @@ func TestInitPaths(t *testing.T) {
 	k := KeepalivedContainerCollectorHost{}
 	k.initPaths("/custom-tmp")
 
+	if k.tmpDir != "/custom-tmp" {
+		t.Fail()
+	}
+
 	if k.jsonPath != "/custom-tmp/keepalived.json" {
 		t.Fail()
 	}
@@ -23,6 +27,14 @@ func TestInitPaths(t *testing.T) {
 	if k.dataPath != "/custom-tmp/keepalived.data" {
 		t.Fail()
 	}
+
+	if k.checkPath != "/custom-tmp/keepalived_check.data" {
+		t.Fail()
+	}
+
+	if k.bfdPath != "/custom-tmp/keepalived_bfd.data" {
+		t.Fail()
+	}
 }
 
 func TestHasVRRPScriptStateSupport(t *testing.T) {
@@ -52,3 +64,17 @@ func TestHasVRRPScriptStateSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestKeepalivedVersion(t *testing.T) {
+	t.Parallel()
+
+	c := KeepalivedContainerCollectorHost{}
+	if c.KeepalivedVersion() != "" {
+		t.Fail()
+	}
+
+	c.version = version.Must(version.NewVersion("2.2.8"))
+	if c.KeepalivedVersion() != "2.2.8" {
+		t.Fail()
+	}
+}
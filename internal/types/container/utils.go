@@ -3,8 +3,11 @@ package container
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
+	"path/filepath"
+	"strconv"
 
 	"github.com/moby/moby/client"
 )
@@ -41,3 +44,21 @@ func (k *KeepalivedContainerCollectorHost) dockerExecCmd(cmd []string) (*bytes.B
 
 	return bytes.NewBuffer(data), nil
 }
+
+// containerProcPath returns the /proc path of Keepalived container init process on host.
+func (k *KeepalivedContainerCollectorHost) containerProcPath() (string, error) {
+	rst, err := k.dockerCli.ContainerInspect(context.Background(), k.containerName, client.ContainerInspectOptions{})
+	if err != nil {
+		slog.Error("Error inspecting container", "container", k.containerName, "error", err)
+
+		return "", err
+	}
+
+	if rst.Container.State == nil || rst.Container.State.Pid == 0 {
+		slog.Error("Keepalived container is not running", "container", k.containerName)
+
+		return "", fmt.Errorf("container %s is not running", k.containerName)
+	}
+
+	return filepath.Join("/proc", strconv.Itoa(rst.Container.State.Pid)), nil
+}
@@ -3,9 +3,11 @@ package host
 import (
 	"bytes"
 	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"syscall"
@@ -17,9 +19,11 @@ import (
 
 // KeepalivedHostCollectorHost implements Collector for when Keepalived and Keepalived Exporter are both on a same host.
 type KeepalivedHostCollectorHost struct {
-	pidPath string
-	version *version.Version
-	useJSON bool
+	pidPath   string
+	tmpDir    string
+	namespace string
+	version   *version.Version
+	useJSON   bool
 
 	SIGJSON  syscall.Signal
 	SIGDATA  syscall.Signal
@@ -27,10 +31,14 @@ type KeepalivedHostCollectorHost struct {
 }
 
 // NewKeepalivedHostCollectorHost is creating new instance of KeepalivedHostCollectorHost.
-func NewKeepalivedHostCollectorHost(useJSON bool, pidPath string) *KeepalivedHostCollectorHost {
+// When tmpDir is empty, dump files are read from the tmp directory seen by the Keepalived process.
+// When namespace is set, the kernel state is read from the network namespace Keepalived was started in with --namespace.
+func NewKeepalivedHostCollectorHost(useJSON bool, pidPath, tmpDir, namespace string) *KeepalivedHostCollectorHost {
 	k := &KeepalivedHostCollectorHost{
-		useJSON: useJSON,
-		pidPath: pidPath,
+		useJSON:   useJSON,
+		pidPath:   pidPath,
+		tmpDir:    tmpDir,
+		namespace: namespace,
 	}
 
 	var err error
@@ -43,30 +51,39 @@ func NewKeepalivedHostCollectorHost(useJSON bool, pidPath string) *KeepalivedHos
 	return k
 }
 
-func (k *KeepalivedHostCollectorHost) Refresh() error {
+func (k *KeepalivedHostCollectorHost) Refresh(dumps ...string) error {
 	if k.useJSON {
-		if err := k.signal(k.SIGJSON); err != nil {
-			slog.Error("Failed to send JSON signal to keepalived", "error", err)
+		return collector.WaitForDumps([]string{k.dumpPath("keepalived.json")}, collector.DumpTimeout, func() error {
+			if err := k.signal(k.SIGJSON); err != nil {
+				slog.Error("Failed to send JSON signal to keepalived", "error", err)
 
-			return err
-		}
+				return err
+			}
 
-		return nil
+			return nil
+		})
 	}
 
-	if err := k.signal(k.SIGSTATS); err != nil {
-		slog.Error("Failed to send STATS signal to keepalived", "error", err)
-
-		return err
+	paths := []string{k.dumpPath("keepalived.stats"), k.dumpPath("keepalived.data")}
+	for _, dump := range dumps {
+		paths = append(paths, k.dumpPath(dump))
 	}
 
-	if err := k.signal(k.SIGDATA); err != nil {
-		slog.Error("Failed to send DATA signal to keepalived", "error", err)
+	return collector.WaitForDumps(paths, collector.DumpTimeout, func() error {
+		if err := k.signal(k.SIGSTATS); err != nil {
+			slog.Error("Failed to send STATS signal to keepalived", "error", err)
 
-		return err
-	}
+			return err
+		}
 
-	return nil
+		if err := k.signal(k.SIGDATA); err != nil {
+			slog.Error("Failed to send DATA signal to keepalived", "error", err)
+
+			return err
+		}
+
+		return nil
+	})
 }
 
 func (k *KeepalivedHostCollectorHost) initSignals() {
@@ -123,23 +140,50 @@ func (k *KeepalivedHostCollectorHost) HasJSONSignalSupport() (bool, error) {
 	return false, nil
 }
 
-// Signal sends signal to Keepalived process.
-func (k *KeepalivedHostCollectorHost) signal(signal os.Signal) error {
+// readPID returns the PID of Keepalived process from its PID file.
+func (k *KeepalivedHostCollectorHost) readPID() (int, error) {
 	data, err := os.ReadFile(k.pidPath)
 	if err != nil {
-		slog.Error("Failed to read Keepalived PID file",
-			"path", k.pidPath,
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSuffix(string(data), "\n"))
+}
+
+// dumpPath returns the path of a Keepalived dump file.
+func (k *KeepalivedHostCollectorHost) dumpPath(name string) string {
+	return filepath.Join(k.dumpDir(), name)
+}
+
+// dumpDir returns the tmp directory of Keepalived dump files.
+// Unless tmp directory is configured, it's resolved through the root of Keepalived process,
+// so dump files of Keepalived running with systemd PrivateTmp=yes are found as well.
+func (k *KeepalivedHostCollectorHost) dumpDir() string {
+	if k.tmpDir != "" {
+		return k.tmpDir
+	}
+
+	if pid, err := k.readPID(); err == nil {
+		tmpDir := filepath.Join("/proc", strconv.Itoa(pid), "root", "tmp")
+		if _, err := os.Stat(tmpDir); err == nil {
+			return tmpDir
+		}
+
+		slog.Debug("Failed to access Keepalived tmp directory, falling back to /tmp",
+			"path", tmpDir,
 			"error", err,
 		)
-
-		return err
 	}
 
-	pid, err := strconv.Atoi(strings.TrimSuffix(string(data), "\n"))
+	return "/tmp"
+}
+
+// Signal sends signal to Keepalived process.
+func (k *KeepalivedHostCollectorHost) signal(signal os.Signal) error {
+	pid, err := k.readPID()
 	if err != nil {
-		slog.Error("Failed to parse Keepalived PID",
+		slog.Error("Failed to read Keepalived PID",
 			"path", k.pidPath,
-			"pid", string(data),
 			"error", err,
 		)
 
@@ -200,103 +244,84 @@ func (k *KeepalivedHostCollectorHost) sigNum(sigString string) syscall.Signal {
 }
 
 func (k *KeepalivedHostCollectorHost) JSONVrrps() ([]collector.VRRP, error) {
-	const fileName = "/tmp/keepalived.json"
+	return collector.ParseFile(k.dumpPath("keepalived.json"), collector.ParseJSON)
+}
 
-	f, err := os.Open(fileName)
-	if err != nil {
-		slog.Error("Failed to open JSON VRRP file",
-			"fileName", fileName,
-			"error", err,
-		)
+func (k *KeepalivedHostCollectorHost) StatsVrrps() (map[string]*collector.VRRPStats, error) {
+	return collector.ParseFile(k.dumpPath("keepalived.stats"), collector.ParseStats)
+}
 
-		return nil, err
-	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close file",
-				"fileName", fileName,
-				"error", err,
-			)
-		}
-	}()
+func (k *KeepalivedHostCollectorHost) DataVrrps() (map[string]*collector.VRRPData, error) {
+	return collector.ParseFile(k.dumpPath("keepalived.data"), collector.ParseVRRPData)
+}
 
-	return collector.ParseJSON(f)
+func (k *KeepalivedHostCollectorHost) ScriptVrrps() ([]collector.VRRPScript, error) {
+	return collector.ParseFile(k.dumpPath("keepalived.data"), func(r io.Reader) ([]collector.VRRPScript, error) {
+		return collector.ParseVRRPScript(r), nil
+	})
 }
 
-func (k *KeepalivedHostCollectorHost) StatsVrrps() (map[string]*collector.VRRPStats, error) {
-	const fileName = "/tmp/keepalived.stats"
+func (k *KeepalivedHostCollectorHost) SyncGroupVrrps() ([]collector.VRRPSyncGroup, error) {
+	return collector.ParseFile(k.dumpPath("keepalived.data"), collector.ParseVRRPSyncGroups)
+}
 
-	f, err := os.Open(fileName)
-	if err != nil {
-		slog.Error("Failed to open Stats VRRP file",
-			"fileName", fileName,
-			"error", err,
-		)
+func (k *KeepalivedHostCollectorHost) TrackFileVrrps() ([]collector.VRRPTrackFile, error) {
+	return collector.ParseFile(k.dumpPath("keepalived.data"), collector.ParseVRRPTrackFiles)
+}
 
-		return nil, err
-	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close Stats VRRP file",
-				"fileName", fileName,
-				"error", err,
-			)
-		}
-	}()
+func (k *KeepalivedHostCollectorHost) TrackProcessVrrps() ([]collector.VRRPTrackProcess, error) {
+	return collector.ParseFile(k.dumpPath("keepalived.data"), collector.ParseVRRPTrackProcesses)
+}
 
-	return collector.ParseStats(f)
+func (k *KeepalivedHostCollectorHost) GlobalDefinitions() (*collector.GlobalDefinitions, error) {
+	return collector.ParseFile(k.dumpPath("keepalived.data"), collector.ParseGlobalDefinitions)
 }
 
-func (k *KeepalivedHostCollectorHost) DataVrrps() (map[string]*collector.VRRPData, error) {
-	const fileName = "/tmp/keepalived.data"
+func (k *KeepalivedHostCollectorHost) CheckerVirtualServers() ([]collector.VirtualServer, error) {
+	return collector.ParseFile(k.dumpPath("keepalived_check.data"), collector.ParseCheckerData)
+}
 
-	f, err := os.Open(fileName)
-	if err != nil {
-		slog.Error("Failed to open Data VRRP file",
-			"fileName", fileName,
-			"error", err,
-		)
+func (k *KeepalivedHostCollectorHost) BFDInstances() ([]collector.BFDInstance, error) {
+	return collector.ParseFile(k.dumpPath("keepalived_bfd.data"), collector.ParseBFDData)
+}
 
-		return nil, err
-	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close Data VRRP file",
-				"fileName", fileName,
-				"error", err,
-			)
+func (k *KeepalivedHostCollectorHost) IPVSServices() ([]collector.IPVSService, error) {
+	procPath := "/proc/net/ip_vs"
+
+	// IPVS tables of another network namespace are seen through the Keepalived process
+	if k.namespace != "" {
+		if pid, err := k.readPID(); err == nil {
+			procPath = filepath.Join("/proc", strconv.Itoa(pid), "net", "ip_vs")
 		}
-	}()
+	}
 
-	return collector.ParseVRRPData(f)
+	return collector.ReadIPVS(k.netnsPath(), procPath)
 }
 
-func (k *KeepalivedHostCollectorHost) ScriptVrrps() ([]collector.VRRPScript, error) {
-	const fileName = "/tmp/keepalived.data"
+func (k *KeepalivedHostCollectorHost) InterfaceAddresses() (map[string][]string, error) {
+	return collector.ReadInterfaceAddresses(k.netnsPath())
+}
 
-	f, err := os.Open(fileName)
-	if err != nil {
-		slog.Error("Failed to open Script VRRP file",
-			"fileName", fileName,
-			"error", err,
-		)
+func (k *KeepalivedHostCollectorHost) RoutingTables() (*collector.RoutingTables, error) {
+	return collector.ReadRoutingTables(k.netnsPath())
+}
 
-		return nil, err
+// netnsPath returns the path of the network namespace named like Keepalived --namespace, or empty for the current one.
+func (k *KeepalivedHostCollectorHost) netnsPath() string {
+	if k.namespace == "" {
+		return ""
+	}
+
+	return filepath.Join("/var/run/netns", k.namespace)
+}
+
+// KeepalivedVersion returns detected Keepalived version or empty string when detection failed.
+func (k *KeepalivedHostCollectorHost) KeepalivedVersion() string {
+	if k.version == nil {
+		return ""
 	}
-	defer func() {
-		err := f.Close()
-		if err != nil {
-			slog.Error("Failed to close Script VRRP file",
-				"fileName", fileName,
-				"error", err,
-			)
-		}
-	}()
 
-	return collector.ParseVRRPScript(f), nil
+	return k.version.String()
 }
 
 // HasVRRPScriptStateSupport check if Keepalived version supports VRRP Script State in output.
@@ -1,6 +1,9 @@
 package host
 
 import (
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/hashicorp/go-version"
@@ -33,3 +36,42 @@ func TestHasVRRPScriptStateSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestKeepalivedVersion(t *testing.T) {
+	t.Parallel()
+
+	c := KeepalivedHostCollectorHost{}
+	if c.KeepalivedVersion() != "" {
+		t.Fail()
+	}
+
+	c.version = version.Must(version.NewVersion("2.2.8"))
+	if c.KeepalivedVersion() != "2.2.8" {
+		t.Fail()
+	}
+}
+
+func TestDumpPath(t *testing.T) {
+	t.Parallel()
+
+	c := KeepalivedHostCollectorHost{tmpDir: "/custom-tmp", pidPath: "/nonexistent/keepalived.pid"}
+	if c.dumpPath("keepalived.data") != "/custom-tmp/keepalived.data" {
+		t.Fail()
+	}
+
+	c.tmpDir = ""
+	if c.dumpPath("keepalived.data") != "/tmp/keepalived.data" {
+		t.Fail()
+	}
+
+	pidPath := filepath.Join(t.TempDir(), "keepalived.pid")
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// tmp directory is resolved through the root of keepalived process
+	c.pidPath = pidPath
+	if c.dumpPath("keepalived.stats") != filepath.Join("/proc", strconv.Itoa(os.Getpid()), "root", "tmp", "keepalived.stats") {
+		t.Fail()
+	}
+}
@@ -0,0 +1,91 @@
+package host
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Target is a Keepalived daemon of the host which is monitored under its own name.
+type Target struct {
+	Name      string
+	PIDPath   string
+	TmpDir    string
+	Namespace string
+}
+
+// ParseTarget parses a target in "name=r1,pid-path=/var/run/keepalived/r1.pid,tmp-dir=/tmp,namespace=r1" format.
+// Name and PID path are required, tmp directory and namespace are optional.
+func ParseTarget(value string) (Target, error) {
+	var t Target
+
+	for _, option := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(option), "=")
+		if !ok || val == "" {
+			return t, fmt.Errorf("invalid target option %q, expected key=value", option)
+		}
+
+		switch key {
+		case "name":
+			t.Name = val
+		case "pid-path":
+			t.PIDPath = val
+		case "tmp-dir":
+			t.TmpDir = val
+		case "namespace":
+			t.Namespace = val
+		default:
+			return t, fmt.Errorf("unknown target option %q", key)
+		}
+	}
+
+	if t.Name == "" {
+		return t, errors.New("target name is required")
+	}
+
+	if t.PIDPath == "" {
+		return t, fmt.Errorf("pid-path of target %s is required", t.Name)
+	}
+
+	return t, nil
+}
+
+// ValidateTargets checks that targets don't read each other's dump files.
+// Tmp directories are resolved like the collector does, so a target without tmp directory is valid
+// as long as its Keepalived process has a tmp directory of its own, like with systemd PrivateTmp=yes.
+func ValidateTargets(targets []Target) error {
+	if len(targets) < 2 {
+		return nil
+	}
+
+	type dumpDir struct {
+		target string
+		path   string
+		info   os.FileInfo
+	}
+
+	dirs := make([]dumpDir, 0, len(targets))
+
+	for _, t := range targets {
+		k := &KeepalivedHostCollectorHost{pidPath: t.PIDPath, tmpDir: t.TmpDir}
+		dir := dumpDir{target: t.Name, path: filepath.Clean(k.dumpDir())}
+
+		// a directory which doesn't exist yet can only be compared by its path
+		info, err := os.Stat(dir.path)
+		if err == nil {
+			dir.info = info
+		}
+
+		for _, other := range dirs {
+			if other.path == dir.path || (other.info != nil && dir.info != nil && os.SameFile(other.info, dir.info)) {
+				return fmt.Errorf("targets %s and %s share tmp directory %s, set a distinct tmp-dir for them", other.target, t.Name, dir.path)
+			}
+		}
+
+		dirs = append(dirs, dir)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseTarget(t *testing.T) {
+	t.Parallel()
+
+	target, err := ParseTarget("name=r1,pid-path=/var/run/keepalived/r1/keepalived.pid,tmp-dir=/tmp/r1,namespace=r1")
+	if err != nil {
+		t.Log(err)
+		t.Fail()
+	}
+
+	expected := Target{Name: "r1", PIDPath: "/var/run/keepalived/r1/keepalived.pid", TmpDir: "/tmp/r1", Namespace: "r1"}
+	if !reflect.DeepEqual(target, expected) {
+		t.Fail()
+	}
+
+	target, err = ParseTarget("name=r2, pid-path=/var/run/keepalived-r2.pid")
+	if err != nil || !reflect.DeepEqual(target, Target{Name: "r2", PIDPath: "/var/run/keepalived-r2.pid"}) {
+		t.Fail()
+	}
+
+	for _, invalid := range []string{
+		"",
+		"pid-path=/var/run/keepalived.pid",
+		"name=r1",
+		"name=r1,pid-path=/var/run/keepalived.pid,netns=r1",
+		"name=r1,pid-path",
+		"name=,pid-path=/var/run/keepalived.pid",
+	} {
+		if _, err := ParseTarget(invalid); err == nil {
+			t.Log(invalid)
+			t.Fail()
+		}
+	}
+}
+
+func TestValidateTargets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r1, r2 := filepath.Join(dir, "r1"), filepath.Join(dir, "r2")
+
+	for _, d := range []string{r1, r2} {
+		if err := os.Mkdir(d, 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Symlink(r1, filepath.Join(dir, "r1-link")); err != nil {
+		t.Fatal(err)
+	}
+
+	pidPath := filepath.Join(dir, "keepalived.pid")
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	missingPIDPath := filepath.Join(dir, "missing.pid")
+
+	valid := [][]Target{
+		nil,
+		{{Name: "r1", PIDPath: missingPIDPath}},
+		{{Name: "r1", PIDPath: pidPath, TmpDir: r1}, {Name: "r2", PIDPath: pidPath, TmpDir: r2}},
+		// tmp directory of r2 is resolved through the root of its process
+		{{Name: "r1", PIDPath: missingPIDPath, TmpDir: r1}, {Name: "r2", PIDPath: pidPath}},
+	}
+	for _, targets := range valid {
+		if err := ValidateTargets(targets); err != nil {
+			t.Log(err)
+			t.Fail()
+		}
+	}
+
+	invalid := [][]Target{
+		{{Name: "r1", PIDPath: pidPath, TmpDir: r1}, {Name: "r2", PIDPath: pidPath, TmpDir: r1 + "/"}},
+		{{Name: "r1", PIDPath: pidPath, TmpDir: r1}, {Name: "r2", PIDPath: pidPath, TmpDir: filepath.Join(dir, "r1-link")}},
+		{{Name: "r1", PIDPath: pidPath, TmpDir: filepath.Join(dir, "r3")}, {Name: "r2", PIDPath: pidPath, TmpDir: filepath.Join(dir, "r3")}},
+		// both fall back to /tmp
+		{{Name: "r1", PIDPath: missingPIDPath}, {Name: "r2", PIDPath: missingPIDPath}},
+		// the test process has no private tmp directory, so its root tmp is /tmp
+		{{Name: "r1", PIDPath: missingPIDPath, TmpDir: "/tmp"}, {Name: "r2", PIDPath: pidPath}},
+	}
+	for _, targets := range invalid {
+		if err := ValidateTargets(targets); err == nil {
+			t.Log(targets)
+			t.Fail()
+		}
+	}
+}
+
+func TestNetnsPath(t *testing.T) {
+	t.Parallel()
+
+	c := KeepalivedHostCollectorHost{}
+	if c.netnsPath() != "" {
+		t.Fail()
+	}
+
+	c.namespace = "r1"
+	if c.netnsPath() != "/var/run/netns/r1" {
+		t.Fail()
+	}
+}